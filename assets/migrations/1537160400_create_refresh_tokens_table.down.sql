@@ -0,0 +1,3 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE IF EXISTS refresh_tokens;
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  family_id                      VARCHAR(64)                            NOT NULL,
+  token_hash                     VARCHAR(64)                            NOT NULL,
+  used_at                        TIMESTAMP WITH TIME ZONE               NULL,
+  revoked_at                     TIMESTAMP WITH TIME ZONE               NULL,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_refresh_tokens_token_hash_index ON refresh_tokens(token_hash);
+CREATE INDEX refresh_tokens_family_id_index ON refresh_tokens(family_id);
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS revoked_tokens
+(
+  jti                            VARCHAR(64)                            NOT NULL PRIMARY KEY,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE INDEX revoked_tokens_expired_at_index ON revoked_tokens(expired_at);
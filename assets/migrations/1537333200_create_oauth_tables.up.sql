@@ -0,0 +1,34 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS oauth_clients
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  client_id                      VARCHAR(64)                            NOT NULL,
+  name                           VARCHAR                                NOT NULL,
+  redirect_uris                  TEXT                                   NOT NULL, -- one redirect uri per line
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL,
+  updated_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_oauth_clients_client_id_index ON oauth_clients(client_id);
+
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  code_hash                      VARCHAR(64)                            NOT NULL,
+  client_id                      VARCHAR(64)                            NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  redirect_uri                   TEXT                                   NOT NULL,
+  scope                          VARCHAR                                NOT NULL DEFAULT '',
+  code_challenge                 VARCHAR(128)                           NOT NULL,
+  code_challenge_method          VARCHAR(16)                            NOT NULL,
+  nonce                          VARCHAR                                NOT NULL DEFAULT '',
+  auth_time                      TIMESTAMP WITH TIME ZONE               NOT NULL,
+  used_at                        TIMESTAMP WITH TIME ZONE               NULL,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_oauth_authorization_codes_code_hash_index ON oauth_authorization_codes(code_hash);
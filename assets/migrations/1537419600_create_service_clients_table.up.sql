@@ -0,0 +1,15 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS service_clients
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  client_id                      VARCHAR(64)                            NOT NULL,
+  name                           VARCHAR                                NOT NULL,
+  secret_hash                    VARCHAR                                NOT NULL, -- bcrypt hash of client secret
+  disabled_at                    TIMESTAMP WITH TIME ZONE               NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL,
+  updated_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_service_clients_client_id_index ON service_clients(client_id);
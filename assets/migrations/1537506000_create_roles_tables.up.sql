@@ -0,0 +1,49 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS roles
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  name                           VARCHAR(64)                            NOT NULL,
+  description                    VARCHAR                                NOT NULL DEFAULT '',
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL,
+  updated_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_roles_name_index ON roles(name);
+
+CREATE TABLE IF NOT EXISTS permissions
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  name                           VARCHAR(64)                            NOT NULL,
+  description                    VARCHAR                                NOT NULL DEFAULT '',
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL,
+  updated_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_permissions_name_index ON permissions(name);
+
+CREATE TABLE IF NOT EXISTS role_permissions
+(
+  role_id                        BIGINT                                 NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+  permission_id                  BIGINT                                 NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL,
+  PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles
+(
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role_id                        BIGINT                                 NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL,
+  PRIMARY KEY (user_id, role_id)
+);
+
+
+CREATE INDEX user_roles_role_id_index ON user_roles(role_id);
+
+-- default role, assign it manually to the first administrator
+INSERT INTO roles (name, description) VALUES ('admin', 'Manage all users');
+INSERT INTO permissions (name, description) VALUES ('users:read', 'See all users'), ('users:write', 'Create, update and delete any user');
+INSERT INTO role_permissions (role_id, permission_id) SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.name = 'admin';
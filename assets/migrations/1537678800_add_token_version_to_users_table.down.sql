@@ -0,0 +1,3 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
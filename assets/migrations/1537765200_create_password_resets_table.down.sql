@@ -0,0 +1,5 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE IF EXISTS password_resets;
+DROP INDEX IF EXISTS unique_users_email_index;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email VARCHAR(254) NULL;
+
+CREATE UNIQUE INDEX unique_users_email_index ON users(email);
+
+CREATE TABLE IF NOT EXISTS password_resets
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token_hash                     VARCHAR(64)                            NOT NULL,
+  used_at                        TIMESTAMP WITH TIME ZONE               NULL,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_password_resets_token_hash_index ON password_resets(token_hash);
+CREATE INDEX password_resets_user_id_index ON password_resets(user_id);
@@ -0,0 +1,18 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE TABLE IF NOT EXISTS email_verifications
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  email                          VARCHAR(254)                           NOT NULL,
+  token_hash                     VARCHAR(64)                            NOT NULL,
+  used_at                        TIMESTAMP WITH TIME ZONE               NULL,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_email_verifications_token_hash_index ON email_verifications(token_hash);
+CREATE INDEX email_verifications_user_id_index ON email_verifications(user_id);
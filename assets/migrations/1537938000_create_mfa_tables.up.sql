@@ -0,0 +1,32 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64) NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMP WITH TIME ZONE NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_counter BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS mfa_backup_codes
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  code_hash                      VARCHAR(64)                            NOT NULL,
+  used_at                        TIMESTAMP WITH TIME ZONE               NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE INDEX mfa_backup_codes_user_id_index ON mfa_backup_codes(user_id);
+
+CREATE TABLE IF NOT EXISTS mfa_challenges
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token_hash                     VARCHAR(64)                            NOT NULL,
+  nonce                          VARCHAR                                NOT NULL DEFAULT '',
+  attempts                       INT                                    NOT NULL DEFAULT 0,
+  used_at                        TIMESTAMP WITH TIME ZONE               NULL,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_mfa_challenges_token_hash_index ON mfa_challenges(token_hash);
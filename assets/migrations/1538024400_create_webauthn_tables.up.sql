@@ -0,0 +1,32 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS webauthn_credentials
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  user_id                        BIGINT                                 NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  credential_id                  VARCHAR(1400)                          NOT NULL,
+  public_key                     BYTEA                                  NOT NULL,
+  sign_count                     BIGINT                                 NOT NULL DEFAULT 0,
+  name                           VARCHAR(100)                           NOT NULL DEFAULT '',
+  last_used_at                   TIMESTAMP WITH TIME ZONE               NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_webauthn_credentials_credential_id_index ON webauthn_credentials(credential_id);
+CREATE INDEX webauthn_credentials_user_id_index ON webauthn_credentials(user_id);
+
+CREATE TABLE IF NOT EXISTS webauthn_sessions
+(
+  id                             BIGSERIAL                              NOT NULL PRIMARY KEY,
+  challenge_hash                 VARCHAR(64)                            NOT NULL,
+  ceremony                       VARCHAR(20)                            NOT NULL,
+  user_id                        BIGINT                                 NULL REFERENCES users(id) ON DELETE CASCADE,
+  nonce                          VARCHAR                                NOT NULL DEFAULT '',
+  used_at                        TIMESTAMP WITH TIME ZONE               NULL,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  created_at                     TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+
+CREATE UNIQUE INDEX unique_webauthn_sessions_challenge_hash_index ON webauthn_sessions(challenge_hash);
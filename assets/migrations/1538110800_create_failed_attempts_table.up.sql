@@ -0,0 +1,11 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS failed_attempts
+(
+  attempt_key                    VARCHAR(255)                           NOT NULL PRIMARY KEY,
+  failures                       INT                                    NOT NULL DEFAULT 0,
+  last_failed_at                 TIMESTAMP WITH TIME ZONE               NOT NULL
+);
+
+
+CREATE INDEX failed_attempts_last_failed_at_index ON failed_attempts(last_failed_at);
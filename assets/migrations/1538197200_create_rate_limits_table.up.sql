@@ -0,0 +1,13 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS rate_limits
+(
+  limit_key                      VARCHAR(255)                           NOT NULL,
+  window_start                   TIMESTAMP WITH TIME ZONE               NOT NULL,
+  hits                           INT                                    NOT NULL DEFAULT 0,
+  expired_at                     TIMESTAMP WITH TIME ZONE               NOT NULL,
+  PRIMARY KEY (limit_key, window_start)
+);
+
+
+CREATE INDEX rate_limits_expired_at_index ON rate_limits(expired_at);
@@ -0,0 +1,4 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+-- it fails when there is argon2id hash, those users must reset the password first
+ALTER TABLE users ALTER COLUMN password TYPE VARCHAR(60);
@@ -0,0 +1,4 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+-- bcrypt hash is always 60 characters, but argon2id hash is longer and its length depends on the parameters
+ALTER TABLE users ALTER COLUMN password TYPE VARCHAR(255);
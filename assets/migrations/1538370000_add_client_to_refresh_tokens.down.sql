@@ -0,0 +1,4 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE refresh_tokens DROP COLUMN scope;
+ALTER TABLE refresh_tokens DROP COLUMN client_id;
@@ -0,0 +1,5 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+-- refresh token issued to OAuth client keeps the client and scope, so the rotated access token is still bound to that client
+ALTER TABLE refresh_tokens ADD COLUMN client_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN scope VARCHAR NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+-- refresh token family issued using the code, it is revoked when the code is used again
+ALTER TABLE oauth_authorization_codes ADD COLUMN family_id VARCHAR(64) NULL;
@@ -0,0 +1,3 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE oauth_authorization_codes DROP COLUMN redirect_uri_explicit;
@@ -0,0 +1,4 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+-- token request only needs redirect_uri when it is sent in the authorization request, existing codes keep requiring it
+ALTER TABLE oauth_authorization_codes ADD COLUMN redirect_uri_explicit BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,4 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+-- it fails when there is encrypted secret, disable two-factor authentication of those users first
+ALTER TABLE users ALTER COLUMN totp_secret TYPE VARCHAR(64);
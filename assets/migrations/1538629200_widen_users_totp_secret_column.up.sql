@@ -0,0 +1,4 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+-- the secret is encrypted using the server secret key, the nonce and tag make it longer than the base32 secret
+ALTER TABLE users ALTER COLUMN totp_secret TYPE VARCHAR(255);
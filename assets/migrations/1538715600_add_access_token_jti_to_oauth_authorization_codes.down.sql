@@ -0,0 +1,4 @@
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE oauth_authorization_codes DROP COLUMN access_token_expired_at;
+ALTER TABLE oauth_authorization_codes DROP COLUMN access_token_jti;
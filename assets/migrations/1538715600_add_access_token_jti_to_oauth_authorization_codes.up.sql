@@ -0,0 +1,5 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+-- access token issued using the code, it is revoked when the code is used again
+ALTER TABLE oauth_authorization_codes ADD COLUMN access_token_jti VARCHAR(64) NULL;
+ALTER TABLE oauth_authorization_codes ADD COLUMN access_token_expired_at TIMESTAMP WITH TIME ZONE NULL;
@@ -2,6 +2,44 @@
 // sources:
 // 1536496889_create_users_table.down.sql
 // 1536496889_create_users_table.up.sql
+// 1537160400_create_refresh_tokens_table.down.sql
+// 1537160400_create_refresh_tokens_table.up.sql
+// 1537246800_create_revoked_tokens_table.down.sql
+// 1537246800_create_revoked_tokens_table.up.sql
+// 1537333200_create_oauth_tables.down.sql
+// 1537333200_create_oauth_tables.up.sql
+// 1537419600_create_service_clients_table.down.sql
+// 1537419600_create_service_clients_table.up.sql
+// 1537506000_create_roles_tables.down.sql
+// 1537506000_create_roles_tables.up.sql
+// 1537592400_add_disabled_at_to_users_table.down.sql
+// 1537592400_add_disabled_at_to_users_table.up.sql
+// 1537678800_add_token_version_to_users_table.down.sql
+// 1537678800_add_token_version_to_users_table.up.sql
+// 1537765200_create_password_resets_table.down.sql
+// 1537765200_create_password_resets_table.up.sql
+// 1537851600_create_email_verifications_table.down.sql
+// 1537851600_create_email_verifications_table.up.sql
+// 1537938000_create_mfa_tables.down.sql
+// 1537938000_create_mfa_tables.up.sql
+// 1538024400_create_webauthn_tables.down.sql
+// 1538024400_create_webauthn_tables.up.sql
+// 1538110800_create_failed_attempts_table.down.sql
+// 1538110800_create_failed_attempts_table.up.sql
+// 1538197200_create_rate_limits_table.down.sql
+// 1538197200_create_rate_limits_table.up.sql
+// 1538283600_widen_users_password_column.down.sql
+// 1538283600_widen_users_password_column.up.sql
+// 1538370000_add_client_to_refresh_tokens.down.sql
+// 1538370000_add_client_to_refresh_tokens.up.sql
+// 1538456400_add_family_id_to_oauth_authorization_codes.down.sql
+// 1538456400_add_family_id_to_oauth_authorization_codes.up.sql
+// 1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.down.sql
+// 1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.up.sql
+// 1538629200_widen_users_totp_secret_column.down.sql
+// 1538629200_widen_users_totp_secret_column.up.sql
+// 1538715600_add_access_token_jti_to_oauth_authorization_codes.down.sql
+// 1538715600_add_access_token_jti_to_oauth_authorization_codes.up.sql
 // DO NOT EDIT!
 
 package migrations
@@ -109,6 +147,766 @@ func _1536496889_create_users_tableUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1537160400_create_refresh_tokens_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7b\x00\x84\xff\x2d\x2d\x20\x2b\x6d\x69\x67\x72\x61\x74\x65\x20\x44\x6f\x77\x6e\x0a\x2d\x2d\x20\x53\x51\x4c\x20\x73\x65\x63\x74\x69\x6f\x6e\x20\x27\x44\x6f\x77\x6e\x27\x20\x69\x73\x20\x65\x78\x65\x63\x75\x74\x65\x64\x20\x77\x68\x65\x6e\x20\x74\x68\x69\x73\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x20\x69\x73\x20\x72\x6f\x6c\x6c\x65\x64\x20\x62\x61\x63\x6b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x66\x72\x65\x73\x68\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x03\x00\x97\x16\x31\x7d\x7b\x00\x00\x00")
+
+func _1537160400_create_refresh_tokens_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537160400_create_refresh_tokens_tableDownSql,
+		"1537160400_create_refresh_tokens_table.down.sql",
+	)
+}
+
+func _1537160400_create_refresh_tokens_tableDownSql() (*asset, error) {
+	bytes, err := _1537160400_create_refresh_tokens_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537160400_create_refresh_tokens_table.down.sql", size: 123, mode: os.FileMode(511), modTime: time.Unix(1792298893, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537160400_create_refresh_tokens_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\x41\x6f\x9b\x40\x10\x85\xef\xfb\x2b\xde\x2d\xa0\xd6\xb7\xaa\x17\x9f\xd6\x78\x9c\xac\x8a\xd7\xc9\xb2\xb4\x49\x2f\x08\x99\x49\x59\x25\xc1\x94\x85\xc6\xfd\xf7\x15\x4e\xd7\x69\xa2\x92\xaa\xc9\xbb\xcd\xcc\xe3\x7b\x02\xde\x6c\x86\x77\x77\xee\x5b\x57\xf6\x8c\xbc\x15\xb3\x19\xb2\x8b\x14\xae\x81\xe7\x6d\xef\x76\x0d\x4e\xf2\xf6\x04\xce\x83\xf7\xbc\x1d\x7a\xae\x70\x5f\x73\x83\xbe\x76\x1e\x0f\xcf\x8d\x26\xe7\x51\xb6\xed\xad\xe3\x4a\x24\x86\xa4\x25\x58\xb9\x48\x09\x6a\x05\xbd\xb1\xa0\x4b\x95\xd9\x0c\x1d\x5f\x77\xec\xeb\xa2\xdf\xdd\x70\xe3\x45\x24\x00\x57\xe1\x25\x2d\xd4\x69\x46\x46\xc9\x34\x2c\xfe\xae\x31\x43\xe7\x69\x8a\x73\xa3\xd6\xd2\x5c\xe1\x13\x5d\xbd\x17\xc0\xe0\xb9\x2b\xa6\x23\x16\xea\x54\x69\x1b\xa6\x7f\xd3\x0d\xad\xc8\x90\x4e\x28\x3b\x90\x7d\xe4\xaa\x18\x1b\x8d\x25\xa5\x64\x09\x89\xcc\x12\xb9\xa4\x31\xf8\xba\xbc\x73\xb7\x3f\x27\xa3\x3f\x4b\x93\x9c\x49\x13\x7d\xfc\x10\x87\xd5\x4b\xc1\x23\xf1\xf0\xcd\x8a\xba\xf4\x75\xb8\xbe\x95\x38\x78\xae\x8a\xb2\x0f\xa7\xe7\xb2\x6a\x4d\x99\x95\xeb\x73\x7c\x51\xf6\xec\x30\xe2\xeb\x46\x53\xb8\xff\x56\xa0\x75\xfc\x63\x77\x33\x0d\xfc\x4f\x1a\xef\x5b\xd7\xbd\x9d\xf6\xc7\xdb\x6e\x3b\x2e\xfb\x57\x10\x97\xb4\x92\x79\x6a\xd1\xec\xee\xa3\xf8\x48\x14\xf1\x5c\x88\x50\xf5\x5c\xab\x8b\x9c\xa0\xf4\x92\x2e\x31\x34\xee\xfb\xc0\xc5\xd3\xaa\x17\x8f\x7f\xaf\x70\x4d\xc5\xfb\xb1\x35\x4f\x2d\xd1\xa3\x25\x9e\x07\xf2\x03\xf2\x19\xeb\xd8\xad\x49\xd4\xd1\x11\xcf\xc5\xaf\x01\x00\x0d\x0d\x1b\xa5\xe0\x03\x00\x00")
+
+func _1537160400_create_refresh_tokens_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537160400_create_refresh_tokens_tableUpSql,
+		"1537160400_create_refresh_tokens_table.up.sql",
+	)
+}
+
+func _1537160400_create_refresh_tokens_tableUpSql() (*asset, error) {
+	bytes, err := _1537160400_create_refresh_tokens_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537160400_create_refresh_tokens_table.up.sql", size: 992, mode: os.FileMode(511), modTime: time.Unix(1792298893, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537246800_create_revoked_tokens_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7b\x00\x84\xff\x2d\x2d\x20\x2b\x6d\x69\x67\x72\x61\x74\x65\x20\x44\x6f\x77\x6e\x0a\x2d\x2d\x20\x53\x51\x4c\x20\x73\x65\x63\x74\x69\x6f\x6e\x20\x27\x44\x6f\x77\x6e\x27\x20\x69\x73\x20\x65\x78\x65\x63\x75\x74\x65\x64\x20\x77\x68\x65\x6e\x20\x74\x68\x69\x73\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x20\x69\x73\x20\x72\x6f\x6c\x6c\x65\x64\x20\x62\x61\x63\x6b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x76\x6f\x6b\x65\x64\x5f\x74\x6f\x6b\x65\x6e\x73\x3b\x0a\x03\x00\x47\xd1\xe6\x5d\x7b\x00\x00\x00")
+
+func _1537246800_create_revoked_tokens_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537246800_create_revoked_tokens_tableDownSql,
+		"1537246800_create_revoked_tokens_table.down.sql",
+	)
+}
+
+func _1537246800_create_revoked_tokens_tableDownSql() (*asset, error) {
+	bytes, err := _1537246800_create_revoked_tokens_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537246800_create_revoked_tokens_table.down.sql", size: 123, mode: os.FileMode(511), modTime: time.Unix(1792298949, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537246800_create_revoked_tokens_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x41\x6f\xaa\x40\x14\x85\xf7\xf3\x2b\xce\x4e\xc8\x7b\xec\x5e\xde\xc6\xd5\x08\xd7\x3a\x29\xa2\x85\xa1\xd5\x6e\x08\x91\x9b\x3a\xb5\x05\xc2\x8c\xd5\x9f\xdf\x60\x43\x8d\xa6\x69\x93\x9e\xdd\x9d\x39\xf7\x3b\xb9\x39\x41\x80\x3f\xaf\xe6\xa9\x2b\x1d\x23\x6f\x45\x10\x20\xbb\x8b\x61\x6a\x58\xde\x38\xd3\xd4\x18\xe5\xed\x08\xc6\x82\x8f\xbc\xd9\x3b\xae\x70\xd8\x72\x0d\xb7\x35\x16\x1f\x7b\xbd\xc9\x58\x94\x6d\xfb\x62\xb8\x12\x61\x4a\x52\x13\xb4\x9c\xc4\x04\x35\x45\xb2\xd0\xa0\x95\xca\x74\x86\x8e\xdf\x9a\x1d\x57\x85\x6b\x76\x5c\x5b\xe1\x09\xe0\xd9\x19\x7c\xa3\x7b\x99\x86\x33\x99\x7a\xff\xff\xf9\xc3\xd3\x57\xea\x33\x92\x3c\x8e\xb1\x4c\xd5\x5c\xa6\x6b\xdc\xd2\xfa\xaf\x00\xf6\x96\xbb\xc2\x54\x83\xed\x5a\x13\x75\xa3\x12\x3d\x4c\x3f\xd3\x53\x9a\x52\x4a\x49\x48\xd9\x89\x6c\x3d\x53\xf9\x58\x24\x88\x28\x26\x4d\x08\x65\x16\xca\x88\xfa\x60\x3e\xb6\xa6\xe3\xaa\x28\xdd\x00\xb9\x90\x56\x73\xca\xb4\x9c\x2f\xf1\xa0\xf4\xec\x34\xe2\x71\x91\xd0\xf0\x7f\x15\xdc\x13\x37\x1d\x97\xee\x17\xc4\x88\xa6\x32\x8f\x35\xea\xe6\xe0\xf9\x9f\x44\xe1\x8f\x85\x18\xaa\x52\x49\x44\xab\xab\x72\x8a\xf3\x05\x85\xa9\x2b\x3e\xf6\x77\x5e\x5a\xbc\xb3\xc5\x1f\x8b\xf7\x01\x00\x90\xe4\x0c\x0b\x48\x02\x00\x00")
+
+func _1537246800_create_revoked_tokens_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537246800_create_revoked_tokens_tableUpSql,
+		"1537246800_create_revoked_tokens_table.up.sql",
+	)
+}
+
+func _1537246800_create_revoked_tokens_tableUpSql() (*asset, error) {
+	bytes, err := _1537246800_create_revoked_tokens_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537246800_create_revoked_tokens_table.up.sql", size: 584, mode: os.FileMode(511), modTime: time.Unix(1792298949, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537333200_create_oauth_tablesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xcb\xbd\xaa\x83\x40\x10\xc5\xf1\xde\xa7\x38\x9d\xc5\x65\x9f\xc0\xea\x06\x0d\x08\x42\x3e\xb4\x48\x27\x66\x1d\xe2\x90\xcd\x0e\xec\x8c\x18\xf2\xf4\x61\x93\x3e\xcd\x29\x0e\xff\x9f\x73\xf8\x7b\xf0\x2d\x4d\x46\xa8\x65\x8b\x85\x73\xe8\x4f\x1d\x94\xbc\xb1\x44\x94\xf9\x2c\xc1\x0a\x7a\x92\x5f\x8d\x66\x6c\x0b\x45\xd8\xc2\x8a\x2f\xcc\x19\x2b\x92\x84\x40\x33\xae\x93\xbf\x17\xf5\xf9\x70\xc4\xf0\xbf\xeb\x1a\xb4\x7b\x34\x97\xb6\x1f\x7a\xc8\xb4\xda\x32\xe6\x91\xc4\xaf\x8f\x1b\xbd\xcc\xa4\xd5\xaf\xde\x07\xa6\x68\x5a\x15\xef\x01\x00\x0e\x8a\x2b\x58\xaa\x00\x00\x00")
+
+func _1537333200_create_oauth_tablesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537333200_create_oauth_tablesDownSql,
+		"1537333200_create_oauth_tables.down.sql",
+	)
+}
+
+func _1537333200_create_oauth_tablesDownSql() (*asset, error) {
+	bytes, err := _1537333200_create_oauth_tablesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537333200_create_oauth_tables.down.sql", size: 170, mode: os.FileMode(511), modTime: time.Unix(1792299286, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537333200_create_oauth_tablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x94\xd1\x6f\x9b\x3e\x10\xc7\xdf\xf9\x2b\xee\x2d\xa0\xdf\x8f\x87\x4d\x53\x35\x29\x4f\x34\xb9\xac\x68\x84\xb4\x60\xb6\x74\x2f\x16\xc2\xa7\x62\x29\x31\x0c\x8c\x1a\xed\xaf\x9f\x48\x63\xd6\x74\x38\x4d\xd7\x6e\x7e\x49\x6c\xce\x9f\x3b\x9f\xbe\xf7\xf5\x7d\xf8\x6f\x2b\xef\x9a\x5c\x13\x64\xb5\xe3\xfb\x90\xde\x44\x20\x15\xb4\x54\x68\x59\x29\x98\x64\xf5\x04\x64\x0b\xb4\xa3\xa2\xd3\x24\xe0\xbe\x24\x05\xba\x94\x2d\x3c\xdc\xeb\x83\x64\x0b\x79\x5d\x6f\x24\x09\x67\x96\x60\xc0\x10\x58\x70\x19\x21\x84\x0b\x88\x57\x0c\x70\x1d\xa6\x2c\x85\x2a\xef\x74\xc9\x8b\x8d\x24\xa5\x5b\xc7\x75\x00\xa4\x80\x53\xeb\x32\xfc\x94\x62\x12\x06\x91\x39\x18\x5f\x7d\x8a\x38\x8b\x22\xb8\x4e\xc2\x65\x90\xdc\xc2\x67\xbc\xfd\xdf\x01\x78\xc8\xc4\x6d\x49\xbe\x04\xc9\xec\x2a\x48\xdc\x8b\x0f\x9e\x39\x3a\x45\xef\x89\x2a\xdf\x92\x39\xb7\x13\xcd\xf6\x1c\x62\x43\x42\x36\x54\x68\xde\x35\xb2\x35\x01\xbf\x16\xc3\x35\x33\xff\xcf\x21\x82\xef\x43\xa5\x68\xc0\x42\xd7\x48\xa8\xa9\x81\x8d\x54\xd4\xb7\xa4\xa1\x5c\x93\xe0\xb9\x36\x57\x8f\x16\x0b\x97\x98\xb2\x60\x79\x0d\x5f\x43\x76\xb5\xdf\xc2\xb7\x55\x8c\x30\xc7\x45\x90\x45\x0c\x54\x75\xef\x7a\x47\x0f\xe8\x6a\xf1\x86\x44\xc7\x9b\x3a\x8e\xd1\x50\x16\x87\x37\x19\x42\x18\xcf\x71\x0d\x9d\x92\xdf\x3b\xe2\x47\x1a\x3a\xfc\x72\x29\xb8\x54\x82\x76\xb0\x8a\x8f\x45\xe6\x0e\x01\xde\x74\xc0\xda\xa5\xd9\x5f\xad\x1a\xf9\x63\x2f\x6a\x5e\x54\x82\xfe\x89\x4c\x2b\x41\xbc\xcc\xdb\xd2\x04\x8e\x8a\xea\x25\x32\x1d\x5e\x6d\x3e\xbe\x8a\x08\x09\x2e\x30\xc1\x78\x86\xa9\xb5\xbb\x7d\xe7\xe7\x18\x21\x43\x98\x05\xe9\x2c\x98\x63\x5f\x47\xd7\x52\x63\xad\x62\x3f\xde\x61\xfc\xbc\xbc\xc7\xea\xe8\xc9\xad\x6b\x4d\xfc\x78\xaa\x0c\xe6\x15\x53\xe5\x00\xb4\x45\x55\x9f\x18\xfd\x43\x4b\xcd\xf6\x39\xe2\xa0\xfe\xc9\x64\x50\x40\x51\xe6\x9b\x0d\xa9\x3b\xb2\xc1\xdd\x77\xef\x3f\x7a\x67\xc0\x7f\x27\xf2\x2d\xe9\xb2\x12\x23\xc4\x8b\xb3\x14\xd0\x13\x55\xa5\x8a\xbf\xd6\x80\x7e\xf0\xb8\x96\x36\x73\xb5\x9a\xc8\x38\xfc\x20\x3d\xab\x27\xbd\x80\x78\xa0\xd1\xae\x96\xcd\x1f\x98\xdc\x13\xda\xa3\xfa\xde\xd6\x88\xcf\xb4\xcd\x11\x7f\xdb\xbb\xdc\xde\x7d\x9e\x5a\xe8\x48\xb0\x3b\x04\x7b\x53\xe7\xe7\x00\xf8\x35\x0f\x80\x3a\x08\x00\x00")
+
+func _1537333200_create_oauth_tablesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537333200_create_oauth_tablesUpSql,
+		"1537333200_create_oauth_tables.up.sql",
+	)
+}
+
+func _1537333200_create_oauth_tablesUpSql() (*asset, error) {
+	bytes, err := _1537333200_create_oauth_tablesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537333200_create_oauth_tables.up.sql", size: 2106, mode: os.FileMode(511), modTime: time.Unix(1792299286, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537419600_create_service_clients_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7c\x00\x83\xff\x2d\x2d\x20\x2b\x6d\x69\x67\x72\x61\x74\x65\x20\x44\x6f\x77\x6e\x0a\x2d\x2d\x20\x53\x51\x4c\x20\x73\x65\x63\x74\x69\x6f\x6e\x20\x27\x44\x6f\x77\x6e\x27\x20\x69\x73\x20\x65\x78\x65\x63\x75\x74\x65\x64\x20\x77\x68\x65\x6e\x20\x74\x68\x69\x73\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x20\x69\x73\x20\x72\x6f\x6c\x6c\x65\x64\x20\x62\x61\x63\x6b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x65\x72\x76\x69\x63\x65\x5f\x63\x6c\x69\x65\x6e\x74\x73\x3b\x0a\x03\x00\x17\x4c\x51\xa5\x7c\x00\x00\x00")
+
+func _1537419600_create_service_clients_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537419600_create_service_clients_tableDownSql,
+		"1537419600_create_service_clients_table.down.sql",
+	)
+}
+
+func _1537419600_create_service_clients_tableDownSql() (*asset, error) {
+	bytes, err := _1537419600_create_service_clients_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537419600_create_service_clients_table.down.sql", size: 124, mode: os.FileMode(511), modTime: time.Unix(1792299545, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537419600_create_service_clients_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x4f\x8f\xd3\x30\x10\xc5\xef\xfe\x14\xef\xb6\x89\x20\x37\xc4\x65\x4f\xde\x5d\x2f\x6b\x91\xa6\x6d\xe2\x40\xcb\x25\x4a\xe3\x81\x58\x6a\xdd\x10\x3b\xb4\x7c\x7b\xd4\x36\x46\x50\x91\x0a\x09\xde\xc5\x9a\x7f\xbf\x67\x8d\x26\x49\xf0\x6a\x67\xbe\xf4\xb5\x27\x94\x1d\x4b\x12\x14\xcb\x14\xc6\xc2\x51\xe3\xcd\xde\xe2\xae\xec\xee\x60\x1c\xe8\x48\xcd\xe0\x49\xe3\xd0\x92\x85\x6f\x8d\xc3\x65\xee\xd4\x64\x1c\xea\xae\xdb\x1a\xd2\xec\x31\x17\x5c\x09\x28\xfe\x90\x0a\xc8\x67\x64\x73\x05\xb1\x92\x85\x2a\xe0\xa8\xff\x66\x1a\xaa\x9a\xad\x21\xeb\x1d\x8b\x18\x60\x34\x6e\xe9\x41\xbe\x2b\x44\x2e\x79\x1a\x12\x7f\xd6\xc9\x24\x2b\xd3\x14\x8b\x5c\xce\x78\xbe\xc6\x7b\xb1\x7e\xcd\x80\x8b\x53\x35\x65\xf2\x81\xe7\x8f\x2f\x3c\x8f\xde\xbe\x89\x43\xea\x16\xfd\x44\xb4\xf5\x8e\x42\x7e\x9a\x18\xc2\xbf\x21\x3a\x6a\x7a\xf2\x55\x5b\xbb\x36\x94\xff\x85\x88\x24\xc1\xa6\xe9\xbf\x77\x1e\x67\xe4\xfe\xf3\xb8\x85\xd1\x88\x01\xda\xb8\x7a\xb3\x25\x5d\xd5\x3e\x8c\xff\x2a\x25\x67\xa2\x50\x7c\xb6\xc0\x47\xa9\x5e\xce\x21\x3e\xcd\x33\x11\xea\xa3\xc2\xff\x9b\x9e\x6a\x3f\x09\x9b\xa6\x3d\x89\x67\x5e\xa6\x0a\x76\x7f\x88\xe2\xdf\x36\x32\x74\xfa\x3f\x12\x59\x7c\xcf\x58\x38\xcb\x32\x93\xcb\x52\x40\x66\x4f\x62\x85\xc1\x9a\xaf\x03\x55\x57\x67\x39\xbe\x95\xd1\x95\xb1\x9a\x8e\x98\x67\xd7\x97\x1b\xfd\x6c\x89\xef\xd9\x8f\x01\x00\x0a\x11\x0d\x9a\x42\x03\x00\x00")
+
+func _1537419600_create_service_clients_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537419600_create_service_clients_tableUpSql,
+		"1537419600_create_service_clients_table.up.sql",
+	)
+}
+
+func _1537419600_create_service_clients_tableUpSql() (*asset, error) {
+	bytes, err := _1537419600_create_service_clients_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537419600_create_service_clients_table.up.sql", size: 834, mode: os.FileMode(511), modTime: time.Unix(1792299545, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537506000_create_roles_tablesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcb\xbd\xaa\x83\x40\x10\xc5\xf1\xde\xa7\x38\x9d\xc5\x65\x9f\xc0\xea\x06\x0d\x08\x42\x3e\xb4\x48\x27\x46\x87\x38\x64\xdd\x09\x33\x2b\xe6\xf1\xc3\x92\x36\x21\xed\x39\xbf\xbf\x73\xf8\x5b\xf8\xa6\x43\x24\x94\xb2\x85\xcc\x39\xb4\xa7\x06\x46\x63\x64\x09\xc8\xd3\x98\x83\x0d\xf4\xa4\x71\x8d\x34\x61\x9b\x29\x20\xce\x6c\x78\x87\x89\xb1\x41\xc5\x7b\x9a\x70\x1d\xc6\x7b\x56\x9e\x0f\x47\x74\xff\xbb\xa6\x42\xbd\x47\x75\xa9\xdb\xae\xc5\x6a\xa4\xbd\x8a\x27\x2b\x3e\x83\xf4\xf5\x0f\xd2\x85\xcd\x58\xc2\x37\xf6\x5b\xa8\x78\xb2\x22\x7b\x0d\x00\x81\x82\x25\x6d\xdc\x00\x00\x00")
+
+func _1537506000_create_roles_tablesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537506000_create_roles_tablesDownSql,
+		"1537506000_create_roles_tables.down.sql",
+	)
+}
+
+func _1537506000_create_roles_tablesDownSql() (*asset, error) {
+	bytes, err := _1537506000_create_roles_tablesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537506000_create_roles_tables.down.sql", size: 220, mode: os.FileMode(511), modTime: time.Unix(1792299617, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537506000_create_roles_tablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x54\x3d\x6f\xdb\x30\x10\xdd\xf5\x2b\xde\x26\x0b\x95\x33\x15\x1d\x6a\x74\x50\x6c\x3a\x11\x2a\xcb\x89\x3e\xf2\xd1\x45\x20\x4c\x26\x21\x20\xd3\xaa\x48\x21\xc9\xbf\x2f\x48\x4b\xb6\x94\xc6\x4e\x87\x04\x05\x8a\x72\x23\xef\xee\xf1\xee\xde\xbb\x1b\x8f\xf1\x69\x2d\xee\x6b\xaa\x39\xf2\xca\x19\x8f\x91\x5e\x46\x10\x12\x8a\xaf\xb4\xd8\x48\xb8\x79\xe5\x42\x28\xf0\x27\xbe\x6a\x34\x67\x78\x7c\xe0\x12\xfa\x41\x28\x6c\xe3\x8c\x93\x50\xa0\x55\x55\x0a\xce\x9c\x69\x42\x82\x8c\x20\x0b\x4e\x23\x82\x70\x8e\x78\x99\x81\xdc\x84\x69\x96\xa2\xde\x94\x5c\x39\x23\x07\x10\x0c\xc7\xce\x69\x78\x96\x92\x24\x0c\xa2\xee\xe1\xf5\x63\xa0\xe3\x3c\x8a\x70\x91\x84\x8b\x20\xb9\xc5\x77\x72\xeb\x3b\x80\xa4\x6b\xde\xf9\xbc\x72\xae\x82\x64\x7a\x1e\x24\xa3\x2f\x9f\xbd\xee\xe9\x18\xba\x41\x64\x5c\xad\x6a\x51\xd9\x86\x74\xf6\xdf\x11\xbb\xeb\x5b\x88\x98\x91\x79\x90\x47\x19\x5c\xd7\x80\xaf\x6a\x4e\x35\x67\x05\xd5\x9d\xe3\xe0\x64\xe1\x82\xa4\x59\xb0\xb8\xc0\x75\x98\x9d\xdb\x2b\x7e\x2c\x63\xb2\x43\x91\x9b\xc7\x91\x37\x48\xb7\xa9\xd8\x3b\x22\x3a\xde\xc4\x71\x3a\x5e\xf3\x38\xbc\xcc\x09\xc2\x78\x46\x6e\xd0\x48\xf1\xb3\xe1\x85\xe5\xb5\x30\x5d\x2f\x84\x64\xfc\x09\xcb\x78\xcb\xf5\xc8\xbc\x79\x93\x5d\xf0\x6b\xa2\xa8\x78\xbd\x16\x4a\x89\x8d\xfc\x2f\x8d\x7f\x4f\x1a\x3d\x76\x5f\x08\xa4\x67\xf9\x13\x99\x18\x3d\xf5\xd1\xac\x56\xec\xe3\x61\xc1\x9c\x86\x67\x61\x9c\x75\xb7\x83\xa7\x2b\x06\x09\x99\x93\x84\xc4\x53\xd2\xae\xaa\x91\x60\x9e\xd1\xf2\x8c\x44\x24\x23\x98\x06\xe9\x34\x98\x11\x43\xfa\x3e\x91\x42\xb0\x77\xfd\xb8\x57\xe2\xe1\xef\xdf\x5f\x16\xbd\x31\xc1\xa8\x6d\xab\x3f\x2c\xd3\x73\xde\xa0\xa8\x51\xbc\x2e\xf6\x3b\xde\x5e\x3f\x84\x1c\x83\x7c\xa4\x3b\x7f\x4d\x15\x1f\x4c\x4b\xdb\x50\xbf\x2b\xd0\x1b\x4c\x5f\x3b\x76\x3b\x0e\x8a\xd6\x6b\x3f\x71\x7b\x7e\x3a\x86\x4d\xfc\x78\x0c\xc6\xef\x68\x53\x6a\x8b\xeb\x83\x2a\x25\xee\x25\x84\xc6\x9a\xca\x86\x96\xe5\x33\xf4\x06\xfa\x81\xe3\x4e\xd4\x4a\x83\xb2\xb5\x90\x42\xe9\x9a\xea\x4d\xed\x84\x71\x4a\x92\x0c\x61\x9c\x2d\x6d\xbc\x82\x9d\x66\xbf\xbf\x17\x3d\x5c\x05\x51\x4e\x52\x8c\x5c\x1b\xec\xfa\x70\x17\x54\xd2\x7b\x0e\x5a\x96\x36\x2f\xe5\x7a\x93\x01\x56\x6f\x0e\x8e\x23\xda\xe8\xaf\x35\xa7\xcc\xc0\xa6\x7c\x80\xe9\xef\x1c\x1e\x6b\xa1\xb9\xf1\x98\x5a\x92\xfc\x76\x11\x82\x4a\x06\xc6\x4b\xae\x39\xa8\x7c\xb6\xb9\xbc\x4c\xe5\xe5\xea\x39\x38\x20\x48\x49\x44\xa6\xd9\xb6\x0f\x27\x43\xbb\x3a\x11\x0c\xf3\x64\xb9\xd8\x5a\x07\x26\x5c\x9f\x93\x84\xb4\x61\xa6\x56\x7c\x83\x4b\xd9\x5a\x48\x77\xe2\xfc\x1a\x00\x4f\x7e\xf8\x81\xa1\x09\x00\x00")
+
+func _1537506000_create_roles_tablesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537506000_create_roles_tablesUpSql,
+		"1537506000_create_roles_tables.up.sql",
+	)
+}
+
+func _1537506000_create_roles_tablesUpSql() (*asset, error) {
+	bytes, err := _1537506000_create_roles_tablesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537506000_create_roles_tables.up.sql", size: 2465, mode: os.FileMode(511), modTime: time.Unix(1792299617, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537592400_add_disabled_at_to_users_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xcb\xb1\x0a\xc2\x30\x14\x46\xe1\xbd\x4f\xf1\x6f\x1d\x24\x4f\xe0\x54\x6d\x85\x42\xb4\xda\x56\x70\x93\x34\xb9\xd8\x8b\x31\x81\xdc\x94\xfa\xf8\x52\x5c\x0f\xe7\x53\x0a\xbb\x0f\xbf\x92\xc9\x84\x3a\xae\xa1\x50\x0a\xc3\x4d\x43\xc8\x66\x8e\x01\xe5\x16\x4b\xb0\x80\xbe\x64\x97\x4c\x0e\xeb\x4c\x01\x79\x66\xc1\x1f\x6e\x1b\x0b\x52\xf4\x9e\x1c\x26\x63\xdf\x45\xa5\xc7\xa6\xc7\x58\x1d\x74\x83\x45\x28\x09\xea\xbe\xbb\xe2\xd8\xe9\xfb\xf9\x82\xf6\x84\xe6\xd1\x0e\xe3\x00\xc7\x62\x26\x4f\xee\x69\xf2\xbe\xf8\x0d\x00\xc7\x76\xed\xeb\x8b\x00\x00\x00")
+
+func _1537592400_add_disabled_at_to_users_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537592400_add_disabled_at_to_users_tableDownSql,
+		"1537592400_add_disabled_at_to_users_table.down.sql",
+	)
+}
+
+func _1537592400_add_disabled_at_to_users_tableDownSql() (*asset, error) {
+	bytes, err := _1537592400_add_disabled_at_to_users_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537592400_add_disabled_at_to_users_table.down.sql", size: 139, mode: os.FileMode(511), modTime: time.Unix(1792299670, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537592400_add_disabled_at_to_users_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xcb\xb1\x6a\xc3\x30\x14\x46\xe1\xdd\x4f\xf1\x6f\x1e\x8a\x9e\xa0\x93\x5a\xab\x54\x20\xcb\x6d\x25\xd1\x90\x25\x28\xd6\x25\xbe\xe0\x38\xc2\x92\x49\x1e\x3f\x24\x19\x0f\x9c\x4f\x08\xbc\x9d\xf9\xb4\xc6\x4a\x08\xb9\x11\x02\xee\xd7\x80\x17\x14\x1a\x2b\x5f\x16\xb4\x21\xb7\xe0\x02\xba\xd1\xb8\x55\x4a\xb8\x4e\xb4\xa0\x4e\x5c\xf0\x72\x8f\x89\x0b\x62\xce\x33\x53\x6a\xa4\xf1\xea\x0f\x5e\x7e\x18\x85\xad\xd0\x5a\x20\xbb\x0e\x9f\x83\x09\xbd\x85\xfe\x82\x1d\x3c\xd4\x4e\x3b\xef\x90\xb8\xc4\xe3\x4c\xe9\x10\x2b\xbc\xee\x95\xf3\xb2\xff\xc1\xbf\xf6\xdf\xcf\xc4\x7e\xb0\x0a\x36\x18\xf3\xde\xdc\x07\x00\xa8\x21\x55\x53\xa7\x00\x00\x00")
+
+func _1537592400_add_disabled_at_to_users_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537592400_add_disabled_at_to_users_tableUpSql,
+		"1537592400_add_disabled_at_to_users_table.up.sql",
+	)
+}
+
+func _1537592400_add_disabled_at_to_users_tableUpSql() (*asset, error) {
+	bytes, err := _1537592400_add_disabled_at_to_users_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537592400_add_disabled_at_to_users_table.up.sql", size: 167, mode: os.FileMode(511), modTime: time.Unix(1792299670, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537678800_add_token_version_to_users_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xcb\x41\xae\x82\x30\x14\x46\xe1\x39\xab\xf8\x67\x0c\x5e\xba\x82\x37\x42\xa9\x09\x49\x15\xa5\x35\x71\x66\xb0\xdc\xc8\x0d\xd8\x26\xbd\x45\x5c\xbe\x21\x4e\x4f\xce\xa7\x14\xfe\x5e\xfc\x4c\x7d\x26\xd4\x71\x0d\x85\x52\xb0\x17\x03\x21\x9f\x39\x06\x94\x5b\x2c\xc1\x02\xfa\x90\x5f\x32\x0d\x58\x47\x0a\xc8\x23\x0b\x7e\x70\xdb\x58\x90\xe2\x3c\xd3\x80\x47\xef\xa7\xa2\x32\x4e\x77\x70\xd5\xce\x68\x2c\x42\x49\x50\x77\xed\x19\xfb\xd6\x5c\x8f\x27\x34\x07\xe8\x5b\x63\x9d\x45\x8e\x13\x85\xfb\x9b\x92\x70\x0c\xff\xc5\x77\x00\xa5\x76\x4d\x52\x8d\x00\x00\x00")
+
+func _1537678800_add_token_version_to_users_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537678800_add_token_version_to_users_tableDownSql,
+		"1537678800_add_token_version_to_users_table.down.sql",
+	)
+}
+
+func _1537678800_add_token_version_to_users_tableDownSql() (*asset, error) {
+	bytes, err := _1537678800_add_token_version_to_users_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537678800_add_token_version_to_users_table.down.sql", size: 141, mode: os.FileMode(511), modTime: time.Unix(1792299734, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537678800_add_token_version_to_users_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\x8c\x3d\x8f\x82\x30\x18\xc7\x77\x3e\xc5\x7f\x63\xb8\x34\xb9\xfd\xa6\x72\x14\xd3\xa4\x96\x28\x6d\xe2\x66\x08\x3c\x91\x46\x2d\x0d\x4f\x51\x3f\xbe\x41\xe7\xdf\x8b\x10\xf8\xb9\x87\xcb\xd2\x67\x82\x4f\x85\x10\xe8\x0e\x06\x21\x82\x69\xc8\x61\x8e\x28\x7d\x2a\x11\x18\xf4\xa2\x61\xcd\x34\xe2\x39\x51\x44\x9e\x02\xe3\xdb\x6d\x52\x60\xf4\x29\xdd\x02\x8d\x85\x34\x4e\x1d\xe1\x64\x65\x14\x56\xa6\x85\x21\xeb\x1a\xff\xad\xf1\x7b\x0b\xdd\xc0\xb6\x0e\xea\xa4\x3b\xd7\x21\xcf\x57\x8a\xe7\x07\x2d\xbc\x3d\x2a\xbd\xd3\xd6\x7d\xb8\xf5\xc6\xa0\x56\x8d\xf4\xc6\xe1\xf7\xaf\x78\x0f\x00\xed\x85\x47\xbd\xa5\x00\x00\x00")
+
+func _1537678800_add_token_version_to_users_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537678800_add_token_version_to_users_tableUpSql,
+		"1537678800_add_token_version_to_users_table.up.sql",
+	)
+}
+
+func _1537678800_add_token_version_to_users_tableUpSql() (*asset, error) {
+	bytes, err := _1537678800_add_token_version_to_users_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537678800_add_token_version_to_users_table.up.sql", size: 165, mode: os.FileMode(511), modTime: time.Unix(1792299734, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537765200_create_password_resets_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcc\x4d\x4e\xc3\x30\x10\xc5\xf1\xbd\x4f\xf1\x76\x5d\x20\x9f\x20\xab\x42\x8c\x14\xc9\xb4\xd0\x04\xa9\x3b\xcb\xc4\x23\x3a\xc2\xb1\xc1\x63\x2b\x3d\x3e\xea\xc7\xa2\xdb\x99\xff\xfb\x69\x8d\xa7\x85\xbf\x8b\xaf\x84\x3e\xaf\x49\x69\x8d\xf1\xc3\x42\x68\xae\x9c\x13\x36\x97\xe3\x06\x2c\xa0\x33\xcd\xad\x52\xc0\x7a\xa2\x84\x7a\x62\xc1\x6d\x78\xc9\x58\x50\x72\x8c\x14\xf0\xe5\xe7\x1f\xd5\x1f\xf6\xef\x98\xb6\xcf\xd6\x60\x78\x85\x39\x0e\xe3\x34\xe2\xd7\x8b\xac\xb9\x04\x57\x48\xa8\x4a\x77\xab\x86\x5d\x6f\x8e\x0f\x55\x4b\xfc\xd7\xc8\x35\xa1\x22\x8e\x16\xcf\xd1\x71\x0a\x74\xee\xd4\xd6\x4e\xe6\x70\x57\xaf\x6f\x5c\x81\x97\xbd\xfd\x7c\xdb\x3d\x08\xb4\x78\x8e\x9d\xfa\x1f\x00\x07\x9f\x7d\x50\xda\x00\x00\x00")
+
+func _1537765200_create_password_resets_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537765200_create_password_resets_tableDownSql,
+		"1537765200_create_password_resets_table.down.sql",
+	)
+}
+
+func _1537765200_create_password_resets_tableDownSql() (*asset, error) {
+	bytes, err := _1537765200_create_password_resets_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537765200_create_password_resets_table.down.sql", size: 218, mode: os.FileMode(511), modTime: time.Unix(1792299776, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537765200_create_password_resets_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\x41\x6f\xdb\x30\x0c\x85\xef\xfe\x15\xef\x56\x1b\x5b\x2e\x43\xb7\x4b\x4e\x8a\xcd\xb4\xc2\x14\xa5\x95\xe5\xad\xdd\x45\x30\x62\x61\x11\xd6\x3a\x9e\x65\x23\xf9\xf9\x83\x9d\x68\x59\x82\x25\x1b\xca\x9b\x28\xf2\x7b\x24\xf1\x26\x13\xbc\x7b\x75\xdf\xdb\xb2\xb3\x28\x9a\x68\x32\x41\xfe\x28\xe0\x6a\x78\xbb\xea\xdc\xa6\xc6\x4d\xd1\xdc\xc0\x79\xd8\x9d\x5d\xf5\x9d\xad\xb0\x5d\xdb\x1a\xdd\xda\x79\xec\xfb\x86\x22\xe7\x51\x36\xcd\x8b\xb3\x55\xc4\x84\x26\x05\xcd\x66\x82\xd0\x7b\xdb\x7a\xb0\x2c\x43\xba\x14\xc5\x42\x82\xcf\x21\x97\x1a\xf4\xc4\x73\x9d\xc3\xbe\x96\xee\x05\x5f\x98\x4a\xef\x99\x8a\x3f\x7c\xbc\x4d\x20\x0b\x21\xa6\x51\x94\x2a\x62\x9a\x50\x48\xfe\x58\x10\xb8\xcc\xe8\x09\x7d\xed\x7e\xf6\xd6\x8c\x4c\x33\xb6\x1a\x57\x57\x76\x87\xa5\xdc\x0b\xc5\x63\x32\x39\xb6\xef\x87\x38\xd5\x6c\x4a\xef\xb7\x9b\xb6\x32\xad\xf5\xb6\xf3\x51\x1c\x01\xae\xc2\xb5\x98\xf1\xbb\x9c\x14\x67\x22\x24\xfe\x1e\xc3\x62\xc3\xf8\x78\x50\x7c\xc1\xd4\x33\x3e\xd3\xf3\xfb\x08\xe3\x6c\xe6\xb2\xc4\x8c\xdf\x71\xa9\xc3\xeb\xdf\x74\x45\x73\x52\x24\x53\xca\x0f\x5b\xbb\x2a\x19\x4e\x90\x91\x20\x4d\x48\x59\x9e\xb2\x8c\x06\xe1\x6e\xf3\xc3\xd6\x66\x5d\xfa\x75\x80\x9c\x44\xb8\xfb\xa7\xdb\x24\xa4\xae\x09\x1f\x56\xa9\x4c\xd9\x85\xaf\xf3\xd0\x7c\x41\xb9\x66\x8b\x07\x7c\xe5\xfa\x7e\x7c\xe2\xdb\x52\x52\xf8\x3f\x44\xa0\xd9\x5d\xe3\xda\xcb\xc0\xff\xa5\xfd\x31\xdf\xaa\xb5\x65\xf7\x06\x62\x46\x73\x56\x08\x8d\x7a\xb3\x8d\x93\xdf\xc4\x68\xb0\xd2\x35\x2b\x9e\x79\xc9\x1c\x0f\x7e\x74\xe6\x59\x4d\x7c\xac\x49\xa6\x81\xbd\xf7\xf7\x39\xed\xe0\x9b\xcb\xa8\xde\xdb\xd6\xb8\x2a\x99\x46\xbf\x06\x00\xd2\x28\x52\x0f\xc5\x03\x00\x00")
+
+func _1537765200_create_password_resets_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537765200_create_password_resets_tableUpSql,
+		"1537765200_create_password_resets_table.up.sql",
+	)
+}
+
+func _1537765200_create_password_resets_tableUpSql() (*asset, error) {
+	bytes, err := _1537765200_create_password_resets_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537765200_create_password_resets_table.up.sql", size: 965, mode: os.FileMode(511), modTime: time.Unix(1792299776, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537851600_create_email_verifications_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcb\xbd\x8e\x82\x40\x14\x47\xf1\x7e\x9e\xe2\xdf\x51\x6c\xe6\x09\xa8\xd8\x85\x4d\x48\x46\x51\xc0\xc4\x8e\x8c\xc3\x55\x6e\xe4\x23\x99\x3b\x88\x8f\x6f\x40\x5b\xdb\x93\xf3\xd3\x1a\x3f\x03\xdf\xbc\x0d\x84\x74\x5a\x46\xa5\x35\xaa\xa3\x81\x90\x0b\x3c\x8d\x88\xd6\x18\x81\x05\xf4\x24\x37\x07\x6a\xb1\x74\x34\x22\x74\x2c\x78\xc3\x75\x63\x81\x9f\xfa\x9e\x5a\x5c\xac\xbb\xab\xb4\x2c\x0e\xa8\x93\x5f\x93\x21\xff\x47\x76\xce\xab\xba\x02\x0d\x96\xfb\xe6\x41\x9e\xaf\xec\x36\x26\xb1\x4a\x4c\x9d\x95\x9f\x75\x16\xf2\x82\xcd\xfe\x15\xe6\xb4\xdb\x7f\xc1\xd4\x36\x36\xc4\xea\x35\x00\xe1\x9c\x9c\xe1\xbb\x00\x00\x00")
+
+func _1537851600_create_email_verifications_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537851600_create_email_verifications_tableDownSql,
+		"1537851600_create_email_verifications_table.down.sql",
+	)
+}
+
+func _1537851600_create_email_verifications_tableDownSql() (*asset, error) {
+	bytes, err := _1537851600_create_email_verifications_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537851600_create_email_verifications_table.down.sql", size: 187, mode: os.FileMode(511), modTime: time.Unix(1792299864, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537851600_create_email_verifications_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\x4f\x73\x9b\x30\x10\xc5\xef\xfa\x14\xef\x16\x98\xd6\x97\x4e\xda\x8b\x4f\x32\xac\x13\x4d\xb1\x9c\x08\xd1\x26\xbd\x30\x8c\xd9\xd6\x9a\x26\x98\x22\x68\xfc\xf1\x3b\x10\x93\x74\x52\xec\x66\xb2\x37\x49\xab\xdf\x7b\xfb\x67\x36\xc3\xbb\x7b\xf7\xa3\x29\x5a\x46\x56\x8b\xd9\x0c\xe9\x75\x02\x57\xc1\xf3\xa6\x75\xbb\x0a\x67\x59\x7d\x06\xe7\xc1\x7b\xde\x74\x2d\x97\x78\xd8\x72\x85\x76\xeb\x3c\x1e\xff\xf5\x49\xce\xa3\xa8\xeb\x3b\xc7\xa5\x90\x89\x25\x03\x2b\x17\x09\xa1\xf3\xdc\x78\xc8\x38\x46\xb4\x4e\xb2\x95\x86\x5a\x42\xaf\x2d\xe8\x46\xa5\x36\x05\xdf\x17\xee\x2e\xff\xcd\x8d\xfb\xee\xb8\xcc\x8b\x16\x56\xad\x28\xb5\x72\x75\x85\xaf\xca\x5e\x0e\x47\x7c\x5b\x6b\x82\xce\x92\x64\x2e\x44\x64\x48\x5a\x3a\xd0\x8f\xc3\x36\x83\x2b\x2f\x02\x01\xb8\x12\xa7\x62\xa1\x2e\x52\x32\x4a\x26\xe3\xc5\x74\xf4\xae\x7b\x0f\xb8\x32\x6a\x25\xcd\x2d\x3e\xd3\xed\x7b\x81\xa1\xc2\xfc\xb8\xc4\x42\x5d\x28\x6d\xc7\xd3\xff\xe9\x86\x96\x64\x48\x47\x94\x0e\x64\x1f\xb8\x32\xc4\x5a\x23\xa6\x84\x2c\x21\x92\x69\x24\x63\xea\x85\x87\x72\xc7\xff\xff\xc6\x17\x69\xa2\x4b\x69\x82\x0f\x1f\xcf\xc3\xf1\xee\x84\x70\x4f\x6c\x77\x3f\xb9\xca\xb7\x85\xdf\x8e\xaf\x93\xc4\x4f\xe7\xe1\x6b\x4a\x39\x34\x67\x98\xea\xf8\xf6\x22\x8e\x0e\xfb\x05\xf1\x40\xe3\x7d\xed\x9a\xe3\xc0\xd7\xd2\xfe\xf2\xb7\x69\xb8\x68\xdf\x40\x8c\x69\x29\xb3\xc4\xa2\xda\x3d\x04\xe1\x13\x51\x84\x73\xf1\xb4\xa1\x99\x56\xd7\x19\x41\xe9\x98\x6e\xd0\x55\xee\x57\xc7\xf9\xc4\x86\xe6\xcf\x4d\xcf\x5d\x55\xf2\xbe\x1f\xf6\x44\x5e\xf0\x9c\x17\xce\x47\x8d\x47\xf8\x14\xf5\xb0\x95\xa7\x91\x9d\xe7\x26\x77\x65\x38\x17\x7f\x06\x00\x6a\x99\xdb\x37\x04\x04\x00\x00")
+
+func _1537851600_create_email_verifications_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537851600_create_email_verifications_tableUpSql,
+		"1537851600_create_email_verifications_table.up.sql",
+	)
+}
+
+func _1537851600_create_email_verifications_tableUpSql() (*asset, error) {
+	bytes, err := _1537851600_create_email_verifications_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537851600_create_email_verifications_table.up.sql", size: 1028, mode: os.FileMode(511), modTime: time.Unix(1792299864, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537938000_create_mfa_tablesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcc\xb1\x4e\xc3\x30\x14\x85\xe1\x3d\x4f\x71\xb6\x0e\xc8\x4f\xd0\xa9\xd0\x20\x55\x32\x14\x9a\x20\xb1\x59\xae\x7d\x69\x2c\x5c\x3b\xf2\xbd\x56\x78\x7c\x64\x60\x60\xe9\x90\xf5\xe8\xff\x8e\x52\xb8\xbb\x86\x4b\xb1\x42\xd8\xe7\x25\x75\x4a\x61\x78\xd5\x60\x72\x12\x72\xc2\xa6\x8d\x1b\x04\x06\x7d\x91\xab\x42\x1e\xcb\x44\x09\x32\x05\xc6\x2f\x6c\x59\x60\x94\x1c\x23\x79\x9c\xad\xfb\xec\xf6\xa7\xe3\x0b\xc6\xdd\xbd\xee\x71\x78\x44\xff\x7e\x18\xc6\x01\xd7\x0f\x6b\xdc\x64\x63\xa4\x74\x21\xde\xde\x8e\xda\x45\x9d\x8d\xcb\xbe\x65\x3b\x3d\xf6\xa7\xbf\xae\x32\x15\xc6\x0f\x7c\x38\xea\xb7\xa7\xe7\x7f\x52\xb2\xcc\x26\x5a\x16\xe3\x72\x4d\x42\x65\x25\xa5\x64\xcf\x91\xbc\xb1\xb2\x12\x32\xb9\x42\xb2\xed\xbe\x07\x00\x7a\x87\x93\x9e\x4b\x01\x00\x00")
+
+func _1537938000_create_mfa_tablesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537938000_create_mfa_tablesDownSql,
+		"1537938000_create_mfa_tables.down.sql",
+	)
+}
+
+func _1537938000_create_mfa_tablesDownSql() (*asset, error) {
+	bytes, err := _1537938000_create_mfa_tablesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537938000_create_mfa_tables.down.sql", size: 331, mode: os.FileMode(511), modTime: time.Unix(1792299984, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1537938000_create_mfa_tablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x93\x41\x73\x9b\x3e\x10\xc5\xef\xfa\x14\x7b\x33\xcc\xff\xef\x99\x1c\x3a\xbd\xf8\x24\x83\x9c\x68\x8a\x71\x02\xa2\x4d\x7a\xd1\x28\xb0\x8d\x35\xb1\x05\x45\x62\xe2\x8f\xdf\xc1\x05\xbb\xa6\xa1\x4e\x3d\x9d\x1e\xba\x37\xa1\xc7\x4f\x5a\xbd\xb7\xd3\x29\xfc\xb7\xd5\x4f\xb5\x72\x08\x59\x45\xa6\x53\x48\xef\x22\xd0\x06\x2c\xe6\x4e\x97\x06\x26\x59\x35\x01\x6d\x01\x77\x98\x37\x0e\x0b\x78\x59\xa3\x01\xb7\xd6\x16\xbe\xff\xd7\x8a\xb4\x05\x55\x55\x1b\x8d\x05\xa1\x91\x60\x09\x08\x3a\x8f\x18\x34\x16\x6b\x0b\x34\x0c\x21\x58\x45\xd9\x32\x06\xbe\x80\x78\x25\x80\xdd\xf3\x54\xa4\xe0\x4a\x57\x49\x8b\x79\x8d\x0e\x3e\xd2\x24\xb8\xa1\x89\xf7\xfe\x9d\x0f\x71\x16\x45\xb3\xdf\x06\xa1\x51\x8f\x1b\x2c\xa4\x72\x20\xf8\x92\xa5\x82\x2e\x6f\xe1\x13\x17\x37\xfb\x25\x7c\x5e\xc5\xec\x42\xf2\x46\x59\x27\xf3\xb2\x31\x0e\x6b\x98\xf3\x6b\x1e\x8b\xbd\xa6\xa5\x41\xc8\x16\x34\x8b\x04\x5c\xcd\x08\x09\x12\x46\x05\xeb\xc8\xa7\xa0\xed\x17\x25\x1f\x55\xfe\xdc\x54\x32\x2f\x0b\xb4\xc4\x23\x00\xba\x80\x5f\xd5\x9c\x5f\xa7\x2c\xe1\x34\xea\x3f\xbc\x5e\x87\xab\xdc\x26\x7c\x49\x93\x07\xf8\xc0\x1e\xfe\x27\xb0\x6f\x4d\x8e\x1f\xd1\x35\xd2\x2f\xcf\xd2\x13\xb6\x60\x09\x8b\x03\x96\xee\xc9\xd6\xd3\x85\x0f\xab\x18\x42\x16\x31\xc1\x20\xa0\x69\x40\x43\xd6\x1e\xdc\x36\x28\xd7\xca\xae\x7b\xc6\x69\xfd\x68\xf5\x1b\x0e\xee\x5a\xd9\x1b\xdb\xef\x0d\x6a\xd4\xef\x01\xb1\xa3\xe5\x35\x2a\x37\x0e\x1c\xa5\xf5\x56\x9b\xf2\xc5\xf3\x0f\xf7\x23\xfe\x8c\x1c\xac\xe7\x71\xc8\xee\x7f\x32\x5b\x76\x56\x48\x6d\x0a\xdc\xb5\x8f\x36\x54\x78\x9d\xc2\x3f\x9f\xa2\x7c\xad\x36\x1b\x34\x4f\xff\x76\x86\x5c\xf9\x8c\x66\x3c\x44\x17\x64\xc8\x94\x26\xc7\x7e\x63\x94\xd8\x2f\xcf\x11\x0f\x73\x3f\x99\xb4\xd7\x55\xce\xe1\xb6\x72\xb6\x97\x0d\xea\x2d\x8f\xf4\x1a\xfc\xea\xcf\x87\x1f\x77\x95\xae\x2f\x08\xff\x80\xb6\x12\x7f\x63\x9c\xb2\x98\xdf\x65\xfd\x54\x35\x46\x7f\x6d\x50\x9e\xce\x80\x3c\x06\xe5\x74\xba\x8e\x12\xef\x28\xf1\x67\xe4\xdb\x00\x7a\x16\x22\x15\xf1\x06\x00\x00")
+
+func _1537938000_create_mfa_tablesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1537938000_create_mfa_tablesUpSql,
+		"1537938000_create_mfa_tables.up.sql",
+	)
+}
+
+func _1537938000_create_mfa_tablesUpSql() (*asset, error) {
+	bytes, err := _1537938000_create_mfa_tablesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1537938000_create_mfa_tables.up.sql", size: 1777, mode: os.FileMode(511), modTime: time.Unix(1792299984, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538024400_create_webauthn_tablesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcb\x3d\xca\x83\x40\x10\x87\xf1\xde\x53\xfc\x3b\x8b\x97\x3d\x81\xd5\x1b\x34\x20\x08\xf9\xd0\x22\x5d\x58\xd7\x21\x0e\xd9\xcc\xc2\xce\xc8\xe6\xf8\x41\x72\x80\xb4\x0f\xbf\xc7\x39\xfc\xbd\xf8\x91\xbd\x11\xda\x54\xa4\x72\x0e\xe3\x65\x80\x52\x30\x4e\x82\x7a\x8f\x35\x58\x41\x6f\x0a\x9b\xd1\x82\xb2\x92\xc0\x56\x56\x7c\xc7\x9d\xb1\x22\xa7\x18\x69\xc1\xec\xc3\xb3\x6a\xaf\xa7\x33\xa6\xff\xc3\xd0\xa1\x3f\xa2\xbb\xf5\xe3\x34\xa2\xd0\xec\x37\x5b\xe5\xae\xa4\xca\x49\xb4\xf9\xe1\x42\xa6\x85\xc4\xd8\x47\x6d\xaa\xcf\x00\xe9\x1e\xd7\x9b\xa9\x00\x00\x00")
+
+func _1538024400_create_webauthn_tablesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538024400_create_webauthn_tablesDownSql,
+		"1538024400_create_webauthn_tables.down.sql",
+	)
+}
+
+func _1538024400_create_webauthn_tablesDownSql() (*asset, error) {
+	bytes, err := _1538024400_create_webauthn_tablesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538024400_create_webauthn_tables.down.sql", size: 169, mode: os.FileMode(511), modTime: time.Unix(1792300249, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538024400_create_webauthn_tablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x94\x51\x6f\xda\x30\x10\xc7\xdf\xf3\x29\xee\x8d\x44\x1b\x12\x9b\xaa\xbd\xf0\x64\xc0\x6d\xad\x85\xd0\x26\xce\x56\xf6\x62\xb9\xc9\x89\x58\x0b\x4e\x16\x27\x82\x7e\xfb\x29\x14\x77\x83\x86\xc0\x3a\x69\xbb\x27\x9c\xfb\xf3\xbb\x3b\xeb\xfe\x1e\x0e\xe1\xdd\x5a\xad\x2a\x59\x23\xc4\xa5\x33\x1c\x42\x74\xef\x83\xd2\x60\x30\xa9\x55\xa1\x61\x10\x97\x03\x50\x06\x70\x8b\x49\x53\x63\x0a\x9b\x0c\x35\xd4\x99\x32\xf0\xfc\xbf\x56\xa4\x0c\xc8\xb2\xcc\x15\xa6\xce\x34\xa4\x84\x53\xe0\x64\xe2\x53\x60\xd7\x10\x2c\x38\xd0\x07\x16\xf1\x08\x36\xf8\x28\x9b\x3a\xd3\x22\xa9\x30\x45\x5d\x2b\x99\x1b\xc7\x75\x00\x54\x0a\x7d\x31\x61\x37\x11\x0d\x19\xf1\xed\x87\xee\x68\x2b\x05\xb1\xef\xc3\x5d\xc8\xe6\x24\x5c\xc2\x67\xba\x7c\xef\x00\x34\x06\x2b\x71\xba\xc4\x84\xdd\xb0\x80\xdb\xd3\x79\x7a\x48\xaf\x69\x48\x83\x29\x8d\x76\x64\xe3\xaa\xd4\x83\x45\x00\x33\xea\x53\x4e\x61\x4a\xa2\x29\x99\xd1\xb6\xf0\xaf\x31\x3b\xcb\x7f\x21\xe1\xf4\x96\x84\xee\x87\xab\xd1\xc8\xb3\x1f\x4f\x17\x6e\x89\x65\xf3\x98\xab\x44\x7c\xc7\x27\x9b\x3d\x88\xc9\x92\x53\x62\x0f\x97\x11\x8d\x5a\x69\x91\x14\x8d\xae\x6d\xf6\xaf\x2e\x67\x46\xaf\x49\xec\x73\x18\xb5\x6c\x2d\xd7\x68\x15\xd0\x33\x7f\xef\xf8\xaf\xd9\x83\x41\x0b\xcf\xa5\xa9\x45\x63\x30\x15\xb2\xab\x75\xce\xe6\x34\xe2\x64\x7e\x07\x5f\x19\xbf\xdd\x1d\xe1\xdb\x22\xa0\x36\xbf\x0f\x7b\x0d\x49\x85\xb2\x3e\xc5\xea\xa1\xd9\x9e\x74\xb1\x71\xbd\x97\x56\x1d\x6f\xec\x38\xd6\x09\x71\xc0\xee\x63\x0a\x2c\x98\xd1\x07\x68\xb4\xfa\xd1\xa0\xe8\x72\xc2\x6f\xbf\x85\x4a\x85\xd2\x29\x6e\xdb\xbd\xea\xd2\xba\x07\x5a\x6f\x6c\x6b\x3d\x17\xe9\xa4\xef\x5d\x70\x86\xbb\x57\x79\xe3\x97\xf6\x7b\x8d\x6c\xd0\x18\x55\xe8\x7f\xe1\xe2\x24\x93\x79\x8e\x7a\x85\x22\x93\x26\xb3\xea\x57\xcb\xf4\xe9\xca\xbb\x84\xbe\x23\x62\x85\xeb\x42\x3f\x9d\x59\xcf\x8f\xa3\x8b\x89\xfb\xdb\xb3\xa9\xe3\xb8\xf4\xa5\xf9\xb3\x57\x46\x17\x3a\xe9\xb1\xd9\x7e\x0c\x7b\x3c\x37\xc6\x91\xcb\x4e\x1b\xec\x2d\x2e\xc3\x6d\xa9\xaa\x37\xb8\xec\x88\xb6\xe0\xff\xdd\xb7\x76\xf1\xc5\xe1\x5a\x76\xb8\xcb\x2a\xdd\x43\xa5\x37\x76\x7e\x0e\x00\x46\x77\x8c\x49\x7b\x07\x00\x00")
+
+func _1538024400_create_webauthn_tablesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538024400_create_webauthn_tablesUpSql,
+		"1538024400_create_webauthn_tables.up.sql",
+	)
+}
+
+func _1538024400_create_webauthn_tablesUpSql() (*asset, error) {
+	bytes, err := _1538024400_create_webauthn_tablesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538024400_create_webauthn_tables.up.sql", size: 1915, mode: os.FileMode(511), modTime: time.Unix(1792300249, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538110800_create_failed_attempts_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7c\x00\x83\xff\x2d\x2d\x20\x2b\x6d\x69\x67\x72\x61\x74\x65\x20\x44\x6f\x77\x6e\x0a\x2d\x2d\x20\x53\x51\x4c\x20\x73\x65\x63\x74\x69\x6f\x6e\x20\x27\x44\x6f\x77\x6e\x27\x20\x69\x73\x20\x65\x78\x65\x63\x75\x74\x65\x64\x20\x77\x68\x65\x6e\x20\x74\x68\x69\x73\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x20\x69\x73\x20\x72\x6f\x6c\x6c\x65\x64\x20\x62\x61\x63\x6b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x66\x61\x69\x6c\x65\x64\x5f\x61\x74\x74\x65\x6d\x70\x74\x73\x3b\x0a\x03\x00\x9c\x9f\x8d\x7d\x7c\x00\x00\x00")
+
+func _1538110800_create_failed_attempts_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538110800_create_failed_attempts_tableDownSql,
+		"1538110800_create_failed_attempts_table.down.sql",
+	)
+}
+
+func _1538110800_create_failed_attempts_tableDownSql() (*asset, error) {
+	bytes, err := _1538110800_create_failed_attempts_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538110800_create_failed_attempts_table.down.sql", size: 124, mode: os.FileMode(511), modTime: time.Unix(1792300424, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538110800_create_failed_attempts_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x4f\xc2\x30\x18\xc5\xef\xfd\x2b\xde\x8d\x2d\xba\xc4\x98\x70\xe2\x54\xa1\x84\xc6\xad\xc3\xad\x53\xf0\xb2\x2c\xec\x53\x1a\xc7\x5c\x68\x89\xf8\xdf\x9b\xa1\x25\x61\xe1\xe0\x3b\xf5\x6b\x7f\x7d\x2f\xdf\x8b\x22\xdc\xec\xcc\xfb\xbe\x72\x84\xa2\x63\x51\x84\xfc\x29\x86\x69\x61\x69\xe3\xcc\x67\x8b\x51\xd1\x8d\x60\x2c\xe8\x48\x9b\x83\xa3\x1a\x5f\x5b\x6a\xe1\xb6\xc6\xe2\xf7\x5f\x0f\x19\x8b\xaa\xeb\x1a\x43\x35\x9b\x66\x82\x6b\x01\xcd\x1f\x62\x01\x39\x87\x4a\x35\xc4\x4a\xe6\x3a\xc7\x5b\x65\x1a\xaa\xcb\xca\x39\xda\x75\xce\xb2\x80\x01\x7f\x43\xf9\x41\xdf\xb8\xa2\x67\x9e\x4d\x17\x3c\x0b\xee\xc7\xe3\xd0\xdf\x5d\x51\x1f\xa2\x8a\x38\xc6\x32\x93\x09\xcf\xd6\x78\x14\xeb\x5b\x86\x53\xe2\x61\x4f\xd6\x73\x03\x49\xa5\xfd\x11\xff\x71\x9f\x89\x39\x2f\x62\x8d\xbb\xde\xbb\xa9\xac\x2b\xcf\x2b\x79\xf6\x2c\x2d\x13\x91\x6b\x9e\x2c\xf1\x22\xf5\xe2\x34\xe2\x35\x55\xc2\xbf\x0f\xbc\x59\x38\x61\xcc\x97\x27\xd5\x4c\xac\x86\x75\x95\x97\x81\xa5\x69\x6b\x3a\x22\x55\x43\x2e\xb8\xe4\xc2\x09\xfb\x19\x00\xde\x65\x5b\x6b\xe4\x01\x00\x00")
+
+func _1538110800_create_failed_attempts_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538110800_create_failed_attempts_tableUpSql,
+		"1538110800_create_failed_attempts_table.up.sql",
+	)
+}
+
+func _1538110800_create_failed_attempts_tableUpSql() (*asset, error) {
+	bytes, err := _1538110800_create_failed_attempts_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538110800_create_failed_attempts_table.up.sql", size: 484, mode: os.FileMode(511), modTime: time.Unix(1792300424, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538197200_create_rate_limits_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x78\x00\x87\xff\x2d\x2d\x20\x2b\x6d\x69\x67\x72\x61\x74\x65\x20\x44\x6f\x77\x6e\x0a\x2d\x2d\x20\x53\x51\x4c\x20\x73\x65\x63\x74\x69\x6f\x6e\x20\x27\x44\x6f\x77\x6e\x27\x20\x69\x73\x20\x65\x78\x65\x63\x75\x74\x65\x64\x20\x77\x68\x65\x6e\x20\x74\x68\x69\x73\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x20\x69\x73\x20\x72\x6f\x6c\x6c\x65\x64\x20\x62\x61\x63\x6b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x61\x74\x65\x5f\x6c\x69\x6d\x69\x74\x73\x3b\x0a\x03\x00\x94\x1e\x0f\x2c\x78\x00\x00\x00")
+
+func _1538197200_create_rate_limits_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538197200_create_rate_limits_tableDownSql,
+		"1538197200_create_rate_limits_table.down.sql",
+	)
+}
+
+func _1538197200_create_rate_limits_tableDownSql() (*asset, error) {
+	bytes, err := _1538197200_create_rate_limits_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538197200_create_rate_limits_table.down.sql", size: 120, mode: os.FileMode(511), modTime: time.Unix(1792300544, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538197200_create_rate_limits_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\xc1\x6e\xf2\x30\x10\x84\xef\x7e\x8a\xb9\x91\xe8\x27\xd2\xaf\x4a\x9c\x38\xb9\x60\x84\xd5\x60\x68\xe2\xb4\xd0\x8b\x15\x11\xab\xac\x0a\x21\xc2\xae\xa0\x6f\x5f\x85\xca\x25\x95\x72\xa8\x3a\xa7\xf5\x8e\xed\xfd\xb4\x93\x24\xf8\x77\xa0\xd7\x53\xe9\x2d\x8a\x86\x25\x09\xf2\xc7\x14\x54\xc3\xd9\xad\xa7\x63\x8d\x41\xd1\x0c\x40\x0e\xf6\x62\xb7\xef\xde\x56\x38\xef\x6c\x0d\xbf\x23\x87\xaf\x77\xed\x25\x72\x28\x9b\x66\x4f\xb6\x62\x93\x4c\x70\x2d\xa0\xf9\x7d\x2a\x20\x67\x50\x4b\x0d\xb1\x96\xb9\xce\xd1\x0e\x31\x7b\x3a\x90\x77\x2c\x62\xc0\xb5\x34\x6f\xf6\x03\xbd\x7a\xe2\xd9\x64\xce\xb3\xe8\x6e\x34\x8a\x43\xaf\x47\xed\x00\x55\xa4\xe9\x90\x01\x67\xaa\xab\xe3\xd9\x38\x5f\x9e\x7c\xf0\x3b\xd2\x72\x21\x72\xcd\x17\x2b\x3c\x4b\x3d\xbf\x1e\xf1\xb2\x54\x22\xf8\x3d\x3f\xee\xc8\xbb\xd0\xef\x91\x54\x3a\x94\xf8\x05\x23\xa6\x62\xc6\x8b\x54\xe3\x7f\x4b\x6b\x2f\x0d\x9d\x6c\x65\xca\x3e\xd6\x3f\xd1\xae\x32\xb9\xe0\xd9\x06\x0f\x62\x83\xe8\x7b\xbd\xc3\x1f\x7b\x89\x59\x3c\x66\x2c\xe4\x24\xd5\x54\xac\xbb\xc9\x98\x1b\x95\xa1\xba\xb2\x17\x2c\x55\xd7\x8f\x6e\x7e\x3c\x66\x9f\x03\x00\x82\x30\xa5\x74\x3f\x02\x00\x00")
+
+func _1538197200_create_rate_limits_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538197200_create_rate_limits_tableUpSql,
+		"1538197200_create_rate_limits_table.up.sql",
+	)
+}
+
+func _1538197200_create_rate_limits_tableUpSql() (*asset, error) {
+	bytes, err := _1538197200_create_rate_limits_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538197200_create_rate_limits_table.up.sql", size: 575, mode: os.FileMode(511), modTime: time.Unix(1792300544, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538283600_widen_users_password_columnDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\xcc\xbd\x4e\xc4\x30\x10\xc4\xf1\x3e\x4f\x31\xdd\x81\xc0\x12\xa2\xa0\xa1\x32\x47\x24\x0a\xf3\x15\x02\x12\xa5\x89\xf7\xce\x2b\x72\x36\xda\xdd\x28\x3c\x3e\xb2\x0e\x44\x3b\xfa\xcd\xdf\x39\x9c\x1d\x78\x2f\xd1\x08\xb7\x75\x2d\x9d\x73\x78\x79\x0e\x50\x9a\x8c\x6b\xc1\xa6\x8d\x1b\xb0\x82\xbe\x69\x5a\x8c\x12\xd6\x4c\x05\x96\x59\x71\x3c\x36\xc6\x0a\xa9\xf3\x4c\x09\x1f\x71\xfa\x6c\x11\x36\xec\x22\xcf\xfa\xc7\x49\xa8\x55\xa2\xec\x6b\xb9\xe4\x84\x1c\x35\x9f\xc3\x72\x55\xc2\xa2\x24\x8a\xc3\xa2\x06\x21\x25\x83\x65\xc2\x57\x54\x5d\xab\x24\xec\x58\xd4\x3a\x1f\xc6\x7e\xc0\xe8\x6f\x42\xff\xeb\x8f\xcb\xf6\x31\xbc\xde\x3f\xfc\xeb\xf1\xfd\xa9\xc7\x9b\x1f\xb6\x77\x7e\x38\xb9\xba\x38\xbd\xee\x7e\x06\x00\xca\x09\xd1\x68\xe3\x00\x00\x00")
+
+func _1538283600_widen_users_password_columnDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538283600_widen_users_password_columnDownSql,
+		"1538283600_widen_users_password_column.down.sql",
+	)
+}
+
+func _1538283600_widen_users_password_columnDownSql() (*asset, error) {
+	bytes, err := _1538283600_widen_users_password_columnDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538283600_widen_users_password_column.down.sql", size: 227, mode: os.FileMode(511), modTime: time.Unix(1792300634, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538283600_widen_users_password_columnUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\x8d\x4d\x4b\xc3\x40\x14\x45\xf7\xf9\x15\x77\x57\x45\x03\x52\xa8\x1b\x57\xb1\x04\x5c\xc4\xaf\x98\x08\x2e\x5f\x33\x8f\xcc\x40\x3a\x33\xbc\xf7\x42\xec\xbf\x97\xa1\xe0\xf6\x72\xce\xb9\x75\x8d\xbb\x73\x98\x85\x8c\x31\xe6\xaa\xae\xf1\xf5\xd9\x21\x44\x28\x4f\x16\x52\xc4\x6e\xcc\x3b\x04\x05\xff\xf2\xb4\x1a\x3b\x6c\x9e\x23\xcc\x07\xc5\xd5\x2b\x50\x50\x50\xce\x4b\x60\x57\x0a\xa7\x49\x2e\xd9\xe0\x49\x7d\x31\x69\xd9\xe8\xa2\x78\x7c\xc0\xe4\x49\x68\x32\x16\xbd\xc7\x69\x35\x90\xcc\x29\xee\x83\xfb\x47\x97\x14\x67\x16\x50\x74\x08\xa6\x58\x38\xce\xe6\xe1\x38\x73\x74\x8a\x54\x7e\x19\x99\x84\xce\x5c\x2a\x55\xd3\x0d\x6d\x8f\xa1\x79\xee\x5a\xac\xca\xa2\xb8\x2e\xc7\xf7\x6e\x7c\x7d\x43\x26\xd5\x2d\x89\xc3\xf0\xf3\xd1\xe2\xbb\xe9\x8f\x2f\x4d\x7f\xb3\x3f\x1c\x6e\x9f\xaa\xbf\x01\x00\x7b\x6f\x02\x55\xf9\x00\x00\x00")
+
+func _1538283600_widen_users_password_columnUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538283600_widen_users_password_columnUpSql,
+		"1538283600_widen_users_password_column.up.sql",
+	)
+}
+
+func _1538283600_widen_users_password_columnUpSql() (*asset, error) {
+	bytes, err := _1538283600_widen_users_password_columnUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538283600_widen_users_password_column.up.sql", size: 249, mode: os.FileMode(511), modTime: time.Unix(1792300634, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538370000_add_client_to_refresh_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcb\x31\xae\x82\x40\x14\x85\xe1\x9e\x55\x9c\x8e\xe2\x65\x56\x40\xc5\x13\xba\x51\x14\xb1\x26\x38\x1c\xe5\x06\x9c\x31\x73\xc7\xe0\xf2\x0d\x71\x03\xb6\x7f\xbe\xdf\x18\xfc\x3d\xe4\x1e\x87\x44\x54\x61\xf5\x99\x31\x38\x9f\x2c\x94\x2e\x49\xf0\xc8\xb7\x98\x43\x14\x7c\xd3\xbd\x12\x47\xac\x13\x3d\xd2\x24\x8a\xef\xb8\x31\x51\xc4\xb0\x2c\x1c\x71\x1d\xdc\x9c\x95\xb6\xab\x5b\x74\xe5\xbf\xad\x11\x79\x8b\xd4\xa9\x4f\x61\xa6\x57\x54\x6d\x73\xc4\xae\xb1\x97\xfd\x01\xea\xc2\x93\xc5\xaf\xda\x2d\x42\x9f\x7a\x19\x8b\xec\x33\x00\x74\x3a\x79\x23\xb6\x00\x00\x00")
+
+func _1538370000_add_client_to_refresh_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538370000_add_client_to_refresh_tokensDownSql,
+		"1538370000_add_client_to_refresh_tokens.down.sql",
+	)
+}
+
+func _1538370000_add_client_to_refresh_tokensDownSql() (*asset, error) {
+	bytes, err := _1538370000_add_client_to_refresh_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538370000_add_client_to_refresh_tokens.down.sql", size: 182, mode: os.FileMode(511), modTime: time.Unix(1792302639, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538370000_add_client_to_refresh_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\xb1\x4e\xc4\x30\x10\x04\xd0\xfe\xbe\x62\xba\x80\x20\x1d\xa2\xa1\x32\x97\x20\x0a\x93\x88\x90\xd0\x9e\x8c\xbd\x60\xeb\x82\x6d\x65\x37\x82\xcf\x47\x09\x17\x2a\x8a\x6b\xbd\x33\xcf\x53\x96\xb8\xfa\x0c\x1f\x93\x11\xc2\x90\x77\x65\x89\x97\x67\x8d\x10\xc1\x64\x25\xa4\x88\x62\xc8\x05\x02\x83\xbe\xc9\xce\x42\x0e\x5f\x9e\x22\xc4\x07\xc6\x6f\x6f\x09\x05\x86\xc9\x79\x0c\xe4\x16\x61\xa2\xf7\x89\xd8\x43\xd2\x91\x96\x1b\xcf\xe4\x20\x09\xad\x9a\xc5\xc3\x8e\x81\xa2\xe0\x48\x94\x19\xe2\x69\x7b\x30\xd1\x81\x6d\xca\x74\x0d\x4e\xeb\x61\x4a\x62\x96\x1f\x8d\xb5\xc4\xfc\xc7\x81\x25\x8c\x23\xde\xd2\x1c\x57\x56\xbc\x91\x13\xb2\x53\xba\xaf\x3b\xf4\xea\x5e\xd7\xdb\x8c\xc3\xda\x63\xa8\xaa\xc2\xbe\xd5\xc3\x53\x73\x0a\x1f\x82\xc3\xab\xea\xf6\x8f\xaa\xbb\xb8\xbd\xb9\x44\xd3\xf6\x68\x06\xad\x51\xd5\x0f\x6a\xd0\x3d\x8a\xe2\xee\x4c\x70\xdd\xbd\x61\xff\x43\x3f\x03\x00\x4a\xab\xa6\x41\x69\x01\x00\x00")
+
+func _1538370000_add_client_to_refresh_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538370000_add_client_to_refresh_tokensUpSql,
+		"1538370000_add_client_to_refresh_tokens.up.sql",
+	)
+}
+
+func _1538370000_add_client_to_refresh_tokensUpSql() (*asset, error) {
+	bytes, err := _1538370000_add_client_to_refresh_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538370000_add_client_to_refresh_tokens.up.sql", size: 361, mode: os.FileMode(511), modTime: time.Unix(1792302639, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538456400_add_family_id_to_oauth_authorization_codesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xcb\xb1\x0e\x82\x30\x14\x85\xe1\x9d\xa7\x38\x1b\x83\xe9\x13\x38\xa1\xb0\x55\x51\xc4\x99\xd4\xf6\x2a\x37\x42\x6f\xd2\x96\xa0\x3e\xbd\xa9\x2e\x67\x38\xf9\x7e\xa5\xb0\x99\xf9\x11\x4c\x22\xd4\xb2\xfa\x42\x29\x5c\xce\x1a\x91\x6c\x62\xf1\x28\xf3\x59\x82\x23\xe8\x45\x76\x49\xe4\xb0\x8e\xe4\x91\x46\x8e\xf8\x87\x99\x71\x44\x90\x69\x22\x87\x9b\xb1\xcf\xa2\xd2\x7d\xd3\xa1\xaf\x76\xba\x81\x98\x25\x8d\x43\x1e\x09\xfc\xf9\xf1\xc1\x8a\xa3\x88\xba\x6b\x4f\xd8\xb7\xfa\x7a\x38\xe2\x6e\x66\x9e\xde\x03\xbb\x6d\xf1\x1d\x00\x33\xc2\xdc\x1e\x93\x00\x00\x00")
+
+func _1538456400_add_family_id_to_oauth_authorization_codesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538456400_add_family_id_to_oauth_authorization_codesDownSql,
+		"1538456400_add_family_id_to_oauth_authorization_codes.down.sql",
+	)
+}
+
+func _1538456400_add_family_id_to_oauth_authorization_codesDownSql() (*asset, error) {
+	bytes, err := _1538456400_add_family_id_to_oauth_authorization_codesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538456400_add_family_id_to_oauth_authorization_codes.down.sql", size: 147, mode: os.FileMode(511), modTime: time.Unix(1792302678, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538456400_add_family_id_to_oauth_authorization_codesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8e\x3f\x4f\xc3\x30\x10\x47\xf7\x7c\x8a\xdf\x56\x10\x64\x43\x2c\x4c\xa6\xad\xc4\x60\x8a\x08\x0d\x6b\x64\xc5\xd7\xf8\xd4\xd6\x8e\x7c\x67\xfe\x7d\x7a\x64\x82\xd4\xe5\x96\x77\xef\xdd\xb5\x2d\x6e\xce\x3c\x65\xa7\x84\x7e\x6e\xda\x16\x6f\xaf\x16\x1c\x21\x34\x2a\xa7\x88\x55\x3f\xaf\xc0\x02\xfa\xa2\xb1\x28\x79\x7c\x06\x8a\xd0\xc0\x82\xc5\xab\x4b\x2c\x70\xf3\x7c\x62\xf2\xb5\x90\xe9\x90\x49\x02\x34\x1d\x29\xe2\xe0\xce\x7c\xfa\x06\x8b\x14\xf2\x28\xc2\x71\x82\x06\xc2\x98\x3c\xdd\x82\xb5\xc6\x33\x7d\xa4\xe3\xa5\xbd\xc0\x0a\x8a\x90\x87\x9b\x1c\xc7\xc6\xd8\xfd\xb6\xc3\xde\x3c\xda\x2d\x92\x2b\x1a\x86\x3a\x52\xe6\x9f\xbf\x1f\x86\xaa\x08\xcc\x66\x83\xf5\x8b\xed\x9f\x77\xff\x87\x07\xf6\x78\x37\xdd\xfa\xc9\x74\x57\xf7\x77\xd7\xd8\xf5\xd6\x3e\x34\xbf\x03\x00\x20\x00\xf5\x25\xf7\x00\x00\x00")
+
+func _1538456400_add_family_id_to_oauth_authorization_codesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538456400_add_family_id_to_oauth_authorization_codesUpSql,
+		"1538456400_add_family_id_to_oauth_authorization_codes.up.sql",
+	)
+}
+
+func _1538456400_add_family_id_to_oauth_authorization_codesUpSql() (*asset, error) {
+	bytes, err := _1538456400_add_family_id_to_oauth_authorization_codesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538456400_add_family_id_to_oauth_authorization_codes.up.sql", size: 247, mode: os.FileMode(511), modTime: time.Unix(1792302678, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xcb\xb1\xce\x82\x30\x14\xc5\xf1\x9d\xa7\x38\x1b\xc3\x97\x3e\xc1\x37\xa1\xb0\x55\x51\xc4\xb9\xc1\xf6\x46\x6e\x44\x6a\x6e\x2f\x81\xf8\xf4\xa6\xba\x9c\xe1\xe4\xf7\x37\x06\x7f\x4f\xbe\xcb\xa0\x84\x3a\xae\x73\x61\x0c\x2e\x67\x8b\x44\x5e\x39\xce\x28\xf3\x59\x82\x13\x68\x23\xbf\x28\x05\xac\x23\xcd\xd0\x91\x13\x7e\x61\x66\x9c\x20\x71\x9a\x28\xe0\x36\xf8\x47\x51\xd9\xbe\xe9\xd0\x57\x3b\xdb\x20\x0e\x8b\x8e\x2e\x4f\x14\x7e\x7f\xb9\xf3\x31\x50\x42\xdd\xb5\x27\xec\x5b\x7b\x3d\x1c\x21\x14\x58\xc8\xab\x5b\x84\x1d\x6d\xaf\x89\x3d\xeb\x7f\xf1\x19\x00\xbe\x89\xdd\x53\x9f\x00\x00\x00")
+
+func _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesDownSql,
+		"1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.down.sql",
+	)
+}
+
+func _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesDownSql() (*asset, error) {
+	bytes, err := _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.down.sql", size: 159, mode: os.FileMode(511), modTime: time.Unix(1792302707, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\xce\x31\x53\x84\x30\x14\x04\xe0\x9e\x5f\xb1\xdd\x15\xca\x2f\xb0\xe2\x04\xab\x08\xe3\x49\x6a\x86\x81\x9d\xe3\xcd\x61\x12\x93\xc7\x88\xfe\x7a\x27\x62\x73\xed\x9b\xb7\xdf\x6e\x59\xe2\xe1\x43\xae\x71\x54\xc2\x86\xa2\x2c\xf1\xfe\x66\x20\x0e\x89\x93\x8a\x77\x38\xd9\x70\x82\x24\x70\xe7\xb4\x29\x67\x7c\x2d\x74\xd0\x45\x12\x8e\x5c\x7e\x92\x84\x31\x84\x55\x38\x67\x41\xfd\x8d\x0e\x91\x9f\x1b\x93\xc2\xbb\xf5\x1b\x8e\x9c\x13\x22\x67\x89\x9c\x74\xd8\xa2\x1c\x8e\x68\xb6\x13\x9d\xe6\x4e\x5d\x88\x71\xd3\xc5\x47\xf9\x39\xe0\x7f\xe4\x11\xdc\x25\xa9\xb8\x2b\x26\x3f\x33\xe1\x46\x86\xbf\x06\x89\xf9\x28\x5a\x54\xa6\x6f\x2e\xe8\xab\xb3\x69\xe0\x33\x32\xdc\x49\xc3\x91\xab\xea\x1a\xcf\x9d\xb1\xaf\xed\xdd\x98\x81\x7b\x58\x65\x12\xc5\xb9\xeb\x4c\x53\xb5\x68\xbb\x1e\xad\x35\x06\x75\xf3\x52\x59\xd3\xa3\xbf\xd8\xe6\xa9\xf8\x1d\x00\x1e\x0c\x01\xc2\x2f\x01\x00\x00")
+
+func _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesUpSql,
+		"1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.up.sql",
+	)
+}
+
+func _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesUpSql() (*asset, error) {
+	bytes, err := _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.up.sql", size: 303, mode: os.FileMode(511), modTime: time.Unix(1792302707, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538629200_widen_users_totp_secret_columnDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x34\xcd\x3d\x4f\x85\x50\x0c\xc6\xf1\x9d\x4f\xf1\x6c\x57\xa3\x67\x33\x2e\x4e\x78\x25\x71\xc0\x37\x44\x13\x27\x73\xee\xa1\x48\x23\x9e\x92\xb6\x04\xfd\xf6\x06\xf1\xae\xff\x3c\xfd\x35\x04\x9c\x7d\xf1\x87\x46\x27\xdc\xc8\x92\x8b\x10\xf0\xfc\x54\xc3\x28\x39\x4b\xc6\x6e\x8d\x3b\xb0\x81\xbe\x29\xcd\x4e\x1d\x96\x81\x32\x7c\x60\xc3\x76\xb8\xce\xd8\xa0\x32\x8e\xd4\xe1\x10\xd3\xe7\x8a\xb0\xa3\x8f\x3c\xda\x71\x4e\x4a\x7f\x4a\x4e\xfa\x33\xad\x8c\x51\x52\xf2\x73\x74\x6c\xf1\x30\x12\x7c\x91\xd0\xc7\xe4\xa2\x88\xb3\x0f\x94\x9d\xd3\x86\x4b\x0f\x1f\xc4\x08\xb3\x91\x1a\x7a\x56\xf3\xa2\xac\xdb\xaa\x41\x5b\x5e\xd7\xd5\x7f\xdf\xca\xfe\xa1\x7e\xb9\xbb\x87\x8b\x4f\xef\xdb\x0b\xb4\x6f\x8f\x15\x5e\xcb\x66\x7f\x5b\x36\x27\x97\x17\xa7\x57\xc5\xef\x00\x91\xe5\x29\x37\xf6\x00\x00\x00")
+
+func _1538629200_widen_users_totp_secret_columnDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538629200_widen_users_totp_secret_columnDownSql,
+		"1538629200_widen_users_totp_secret_column.down.sql",
+	)
+}
+
+func _1538629200_widen_users_totp_secret_columnDownSql() (*asset, error) {
+	bytes, err := _1538629200_widen_users_totp_secret_columnDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538629200_widen_users_totp_secret_column.down.sql", size: 246, mode: os.FileMode(511), modTime: time.Unix(1792303080, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538629200_widen_users_totp_secret_columnUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x34\xcd\xbd\x4e\xc3\x40\x10\x04\xe0\xde\x4f\x31\x5d\x40\xe0\x26\x28\x15\x95\x89\x2c\x51\x98\x3f\x63\x23\x51\xa1\xc3\x1e\xd9\xab\x24\xeb\xd3\xdd\x1a\xc8\xdb\xa3\x4b\x9c\x76\x76\xbe\x9d\x3c\xc7\xcd\x41\x86\xe0\x8c\x68\x7d\x96\xe7\x78\x7f\xab\x20\x8a\xc8\xce\x64\x52\xac\x5a\xbf\x82\x44\xf0\x8f\xdd\x6c\xec\xf1\x3b\x52\x61\xa3\x44\x9c\x5d\x2a\x49\x84\xf3\x7e\x2f\xec\xd3\x07\x1b\x99\x78\xa0\x9d\xa0\x76\xe1\xe8\x93\x9c\xa3\xe8\xb0\x5c\xc3\x0f\xc3\xa5\xb4\xe3\xf1\xf6\x14\xeb\xa4\x1d\xe1\xb4\x87\xb9\x01\x07\xb7\x23\xc4\xb0\x9f\x74\x60\x80\x8d\x2e\xed\x12\xdf\x2e\xf2\x6e\xbd\xe0\xac\xa8\x9a\xb2\x46\x53\x3c\x54\x25\xe6\xc8\x10\x71\x4e\xb6\x2f\x55\xfb\xf4\x0c\x9b\xcc\x7f\x2d\x3b\xcd\xe7\x6b\x89\x8f\xa2\xde\x3e\x16\xf5\xd5\x7a\xb3\xb9\xbe\xcf\xfe\x07\x00\x55\xf7\x0d\x9d\xff\x00\x00\x00")
+
+func _1538629200_widen_users_totp_secret_columnUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538629200_widen_users_totp_secret_columnUpSql,
+		"1538629200_widen_users_totp_secret_column.up.sql",
+	)
+}
+
+func _1538629200_widen_users_totp_secret_columnUpSql() (*asset, error) {
+	bytes, err := _1538629200_widen_users_totp_secret_columnUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538629200_widen_users_totp_secret_column.up.sql", size: 255, mode: os.FileMode(511), modTime: time.Unix(1792303080, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538715600_add_access_token_jti_to_oauth_authorization_codesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xcc\x31\x8e\x83\x30\x14\x04\xd0\x9e\x53\x4c\x47\xb1\xf2\x09\xa8\xd8\x85\xce\xbb\x6c\x08\xa9\x2d\xc7\xfe\x0a\x3f\x10\x3b\xb2\x3f\x02\xe5\xf4\x11\xc9\x11\xd2\x4c\x31\x7a\x33\x4a\xe1\xeb\xc6\x97\x64\x85\xd0\xc4\x35\x14\x4a\xe1\x78\xd0\xc8\xe4\x84\x63\x40\xb9\x97\x25\x38\x83\x36\x72\x8b\x90\xc7\x3a\x52\x80\x8c\x9c\xf1\x1e\xee\x8c\x33\x52\x9c\x67\xf2\x38\x5b\x37\x15\xb5\x1e\xda\x1e\x43\xfd\xad\x5b\x44\xbb\xc8\x68\xf6\x88\x89\x1f\x2f\x6e\x5c\xf4\x94\xd1\xf4\xdd\x3f\x7e\x3a\x7d\xfa\xfd\x83\x75\x8e\x72\x36\x12\x27\x0a\x86\xb6\x3b\x27\xf2\xc6\x4a\xf5\xe9\xd5\x55\xb8\x2a\x9e\x03\x00\x8e\x7f\x73\x76\xe5\x00\x00\x00")
+
+func _1538715600_add_access_token_jti_to_oauth_authorization_codesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538715600_add_access_token_jti_to_oauth_authorization_codesDownSql,
+		"1538715600_add_access_token_jti_to_oauth_authorization_codes.down.sql",
+	)
+}
+
+func _1538715600_add_access_token_jti_to_oauth_authorization_codesDownSql() (*asset, error) {
+	bytes, err := _1538715600_add_access_token_jti_to_oauth_authorization_codesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538715600_add_access_token_jti_to_oauth_authorization_codes.down.sql", size: 229, mode: os.FileMode(511), modTime: time.Unix(1792304310, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1538715600_add_access_token_jti_to_oauth_authorization_codesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x8d\xc1\x4a\xc3\x40\x10\x86\xef\x79\x8a\xff\x56\x45\x73\x13\x2f\x3d\xad\x6d\xa0\x85\x4d\xaa\x69\xa2\xe0\x25\x2c\xd9\x21\x19\xab\xd9\x90\x99\x68\xf1\xe9\x65\x53\xc1\x07\xf0\xb2\xb0\x7c\xf3\x7f\x5f\x9a\xe2\xe6\x83\xbb\xc9\x29\xa1\x1e\x93\x34\xc5\xf1\xc9\x82\x07\x08\xb5\xca\x61\xc0\xaa\x1e\x57\x60\x01\x9d\xa9\x9d\x95\x3c\xbe\x7a\x1a\xa0\x3d\x0b\x2e\xbb\x78\xc4\x02\x37\x8e\xef\x4c\x3e\x1a\x5c\xdb\x92\x08\x34\x9c\x28\x22\x99\xc9\x63\x16\x1e\x3a\x68\x4f\x68\x83\xa7\x5b\xb0\x46\xe9\x44\x9f\xe1\xf4\xe7\xbc\xc0\x08\x66\x21\x0f\xd7\x39\x1e\x12\x63\xab\xac\x44\x65\x1e\x6c\x86\xe0\x66\xed\x9b\xf8\x84\x89\xbf\x97\x76\x13\x27\x02\xb3\xdd\x62\x73\xb0\x75\x5e\xfc\xe6\x9b\x25\xdf\xbc\x29\xe3\xd9\x94\x9b\x9d\x29\xaf\xee\xef\xae\x51\xd4\xd6\xae\xff\xe9\xa4\xf3\xc8\x13\xf9\xc6\x29\xaa\x7d\x9e\x1d\x2b\x93\x3f\xe2\x65\x5f\xed\x96\x2f\x5e\x0f\x45\x86\xa2\xb6\x76\x9d\xfc\x0c\x00\x1d\xc4\xd4\xc2\x5e\x01\x00\x00")
+
+func _1538715600_add_access_token_jti_to_oauth_authorization_codesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1538715600_add_access_token_jti_to_oauth_authorization_codesUpSql,
+		"1538715600_add_access_token_jti_to_oauth_authorization_codes.up.sql",
+	)
+}
+
+func _1538715600_add_access_token_jti_to_oauth_authorization_codesUpSql() (*asset, error) {
+	bytes, err := _1538715600_add_access_token_jti_to_oauth_authorization_codesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1538715600_add_access_token_jti_to_oauth_authorization_codes.up.sql", size: 350, mode: os.FileMode(511), modTime: time.Unix(1792304310, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -163,6 +961,44 @@ func AssetNames() []string {
 var _bindata = map[string]func() (*asset, error){
 	"1536496889_create_users_table.down.sql": _1536496889_create_users_tableDownSql,
 	"1536496889_create_users_table.up.sql": _1536496889_create_users_tableUpSql,
+	"1537160400_create_refresh_tokens_table.down.sql": _1537160400_create_refresh_tokens_tableDownSql,
+	"1537160400_create_refresh_tokens_table.up.sql": _1537160400_create_refresh_tokens_tableUpSql,
+	"1537246800_create_revoked_tokens_table.down.sql": _1537246800_create_revoked_tokens_tableDownSql,
+	"1537246800_create_revoked_tokens_table.up.sql": _1537246800_create_revoked_tokens_tableUpSql,
+	"1537333200_create_oauth_tables.down.sql": _1537333200_create_oauth_tablesDownSql,
+	"1537333200_create_oauth_tables.up.sql": _1537333200_create_oauth_tablesUpSql,
+	"1537419600_create_service_clients_table.down.sql": _1537419600_create_service_clients_tableDownSql,
+	"1537419600_create_service_clients_table.up.sql": _1537419600_create_service_clients_tableUpSql,
+	"1537506000_create_roles_tables.down.sql": _1537506000_create_roles_tablesDownSql,
+	"1537506000_create_roles_tables.up.sql": _1537506000_create_roles_tablesUpSql,
+	"1537592400_add_disabled_at_to_users_table.down.sql": _1537592400_add_disabled_at_to_users_tableDownSql,
+	"1537592400_add_disabled_at_to_users_table.up.sql": _1537592400_add_disabled_at_to_users_tableUpSql,
+	"1537678800_add_token_version_to_users_table.down.sql": _1537678800_add_token_version_to_users_tableDownSql,
+	"1537678800_add_token_version_to_users_table.up.sql": _1537678800_add_token_version_to_users_tableUpSql,
+	"1537765200_create_password_resets_table.down.sql": _1537765200_create_password_resets_tableDownSql,
+	"1537765200_create_password_resets_table.up.sql": _1537765200_create_password_resets_tableUpSql,
+	"1537851600_create_email_verifications_table.down.sql": _1537851600_create_email_verifications_tableDownSql,
+	"1537851600_create_email_verifications_table.up.sql": _1537851600_create_email_verifications_tableUpSql,
+	"1537938000_create_mfa_tables.down.sql": _1537938000_create_mfa_tablesDownSql,
+	"1537938000_create_mfa_tables.up.sql": _1537938000_create_mfa_tablesUpSql,
+	"1538024400_create_webauthn_tables.down.sql": _1538024400_create_webauthn_tablesDownSql,
+	"1538024400_create_webauthn_tables.up.sql": _1538024400_create_webauthn_tablesUpSql,
+	"1538110800_create_failed_attempts_table.down.sql": _1538110800_create_failed_attempts_tableDownSql,
+	"1538110800_create_failed_attempts_table.up.sql": _1538110800_create_failed_attempts_tableUpSql,
+	"1538197200_create_rate_limits_table.down.sql": _1538197200_create_rate_limits_tableDownSql,
+	"1538197200_create_rate_limits_table.up.sql": _1538197200_create_rate_limits_tableUpSql,
+	"1538283600_widen_users_password_column.down.sql": _1538283600_widen_users_password_columnDownSql,
+	"1538283600_widen_users_password_column.up.sql": _1538283600_widen_users_password_columnUpSql,
+	"1538370000_add_client_to_refresh_tokens.down.sql": _1538370000_add_client_to_refresh_tokensDownSql,
+	"1538370000_add_client_to_refresh_tokens.up.sql": _1538370000_add_client_to_refresh_tokensUpSql,
+	"1538456400_add_family_id_to_oauth_authorization_codes.down.sql": _1538456400_add_family_id_to_oauth_authorization_codesDownSql,
+	"1538456400_add_family_id_to_oauth_authorization_codes.up.sql": _1538456400_add_family_id_to_oauth_authorization_codesUpSql,
+	"1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.down.sql": _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesDownSql,
+	"1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.up.sql": _1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesUpSql,
+	"1538629200_widen_users_totp_secret_column.down.sql": _1538629200_widen_users_totp_secret_columnDownSql,
+	"1538629200_widen_users_totp_secret_column.up.sql": _1538629200_widen_users_totp_secret_columnUpSql,
+	"1538715600_add_access_token_jti_to_oauth_authorization_codes.down.sql": _1538715600_add_access_token_jti_to_oauth_authorization_codesDownSql,
+	"1538715600_add_access_token_jti_to_oauth_authorization_codes.up.sql": _1538715600_add_access_token_jti_to_oauth_authorization_codesUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -207,6 +1043,44 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"1536496889_create_users_table.down.sql": &bintree{_1536496889_create_users_tableDownSql, map[string]*bintree{}},
 	"1536496889_create_users_table.up.sql": &bintree{_1536496889_create_users_tableUpSql, map[string]*bintree{}},
+	"1537160400_create_refresh_tokens_table.down.sql": &bintree{_1537160400_create_refresh_tokens_tableDownSql, map[string]*bintree{}},
+	"1537160400_create_refresh_tokens_table.up.sql": &bintree{_1537160400_create_refresh_tokens_tableUpSql, map[string]*bintree{}},
+	"1537246800_create_revoked_tokens_table.down.sql": &bintree{_1537246800_create_revoked_tokens_tableDownSql, map[string]*bintree{}},
+	"1537246800_create_revoked_tokens_table.up.sql": &bintree{_1537246800_create_revoked_tokens_tableUpSql, map[string]*bintree{}},
+	"1537333200_create_oauth_tables.down.sql": &bintree{_1537333200_create_oauth_tablesDownSql, map[string]*bintree{}},
+	"1537333200_create_oauth_tables.up.sql": &bintree{_1537333200_create_oauth_tablesUpSql, map[string]*bintree{}},
+	"1537419600_create_service_clients_table.down.sql": &bintree{_1537419600_create_service_clients_tableDownSql, map[string]*bintree{}},
+	"1537419600_create_service_clients_table.up.sql": &bintree{_1537419600_create_service_clients_tableUpSql, map[string]*bintree{}},
+	"1537506000_create_roles_tables.down.sql": &bintree{_1537506000_create_roles_tablesDownSql, map[string]*bintree{}},
+	"1537506000_create_roles_tables.up.sql": &bintree{_1537506000_create_roles_tablesUpSql, map[string]*bintree{}},
+	"1537592400_add_disabled_at_to_users_table.down.sql": &bintree{_1537592400_add_disabled_at_to_users_tableDownSql, map[string]*bintree{}},
+	"1537592400_add_disabled_at_to_users_table.up.sql": &bintree{_1537592400_add_disabled_at_to_users_tableUpSql, map[string]*bintree{}},
+	"1537678800_add_token_version_to_users_table.down.sql": &bintree{_1537678800_add_token_version_to_users_tableDownSql, map[string]*bintree{}},
+	"1537678800_add_token_version_to_users_table.up.sql": &bintree{_1537678800_add_token_version_to_users_tableUpSql, map[string]*bintree{}},
+	"1537765200_create_password_resets_table.down.sql": &bintree{_1537765200_create_password_resets_tableDownSql, map[string]*bintree{}},
+	"1537765200_create_password_resets_table.up.sql": &bintree{_1537765200_create_password_resets_tableUpSql, map[string]*bintree{}},
+	"1537851600_create_email_verifications_table.down.sql": &bintree{_1537851600_create_email_verifications_tableDownSql, map[string]*bintree{}},
+	"1537851600_create_email_verifications_table.up.sql": &bintree{_1537851600_create_email_verifications_tableUpSql, map[string]*bintree{}},
+	"1537938000_create_mfa_tables.down.sql": &bintree{_1537938000_create_mfa_tablesDownSql, map[string]*bintree{}},
+	"1537938000_create_mfa_tables.up.sql": &bintree{_1537938000_create_mfa_tablesUpSql, map[string]*bintree{}},
+	"1538024400_create_webauthn_tables.down.sql": &bintree{_1538024400_create_webauthn_tablesDownSql, map[string]*bintree{}},
+	"1538024400_create_webauthn_tables.up.sql": &bintree{_1538024400_create_webauthn_tablesUpSql, map[string]*bintree{}},
+	"1538110800_create_failed_attempts_table.down.sql": &bintree{_1538110800_create_failed_attempts_tableDownSql, map[string]*bintree{}},
+	"1538110800_create_failed_attempts_table.up.sql": &bintree{_1538110800_create_failed_attempts_tableUpSql, map[string]*bintree{}},
+	"1538197200_create_rate_limits_table.down.sql": &bintree{_1538197200_create_rate_limits_tableDownSql, map[string]*bintree{}},
+	"1538197200_create_rate_limits_table.up.sql": &bintree{_1538197200_create_rate_limits_tableUpSql, map[string]*bintree{}},
+	"1538283600_widen_users_password_column.down.sql": &bintree{_1538283600_widen_users_password_columnDownSql, map[string]*bintree{}},
+	"1538283600_widen_users_password_column.up.sql": &bintree{_1538283600_widen_users_password_columnUpSql, map[string]*bintree{}},
+	"1538370000_add_client_to_refresh_tokens.down.sql": &bintree{_1538370000_add_client_to_refresh_tokensDownSql, map[string]*bintree{}},
+	"1538370000_add_client_to_refresh_tokens.up.sql": &bintree{_1538370000_add_client_to_refresh_tokensUpSql, map[string]*bintree{}},
+	"1538456400_add_family_id_to_oauth_authorization_codes.down.sql": &bintree{_1538456400_add_family_id_to_oauth_authorization_codesDownSql, map[string]*bintree{}},
+	"1538456400_add_family_id_to_oauth_authorization_codes.up.sql": &bintree{_1538456400_add_family_id_to_oauth_authorization_codesUpSql, map[string]*bintree{}},
+	"1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.down.sql": &bintree{_1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesDownSql, map[string]*bintree{}},
+	"1538542800_add_redirect_uri_explicit_to_oauth_authorization_codes.up.sql": &bintree{_1538542800_add_redirect_uri_explicit_to_oauth_authorization_codesUpSql, map[string]*bintree{}},
+	"1538629200_widen_users_totp_secret_column.down.sql": &bintree{_1538629200_widen_users_totp_secret_columnDownSql, map[string]*bintree{}},
+	"1538629200_widen_users_totp_secret_column.up.sql": &bintree{_1538629200_widen_users_totp_secret_columnUpSql, map[string]*bintree{}},
+	"1538715600_add_access_token_jti_to_oauth_authorization_codes.down.sql": &bintree{_1538715600_add_access_token_jti_to_oauth_authorization_codesDownSql, map[string]*bintree{}},
+	"1538715600_add_access_token_jti_to_oauth_authorization_codes.up.sql": &bintree{_1538715600_add_access_token_jti_to_oauth_authorization_codesUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
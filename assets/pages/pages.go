@@ -0,0 +1,258 @@
+// Code generated by go-bindata.
+// sources:
+// authorize.html
+// error.html
+// DO NOT EDIT!
+
+package pages
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _authorizeHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x55\x5b\x6f\xdb\x36\x14\x7e\xcf\xaf\x38\x63\x31\x60\x03\xac\x58\x76\x9b\xae\x53\x24\x01\x41\xda\x62\x7d\x68\x57\xa4\xed\xc3\x50\x14\x06\x45\x1e\x59\x5c\x28\x52\x23\x29\x5b\xae\xe1\xff\x3e\x90\x92\x2f\xca\x12\xcc\x28\x6c\x40\xe4\xb9\x7c\xfc\xce\x85\x87\xe9\x4f\xaf\xff\xbc\xfd\xfc\xd7\xc7\x37\x50\xb9\x5a\xe6\x17\xa9\xff\x80\xa4\x6a\x99\x11\x54\xc4\x0b\x90\xf2\xfc\x02\x20\xad\xd1\x51\x60\x15\x35\x16\x5d\x46\x5a\x57\x46\xaf\xc8\x51\xa1\x68\x8d\x19\x59\x09\x5c\x37\xda\x38\x02\x4c\x2b\x87\xca\x65\x64\x2d\xb8\xab\x32\x8e\x2b\xc1\x30\x0a\x9b\x09\x08\x25\x9c\xa0\x32\xb2\x8c\x4a\xcc\x66\x3d\x8c\x13\x4e\x62\xfe\x49\x2c\x15\x08\x05\x4e\xc3\x76\x0b\x97\xb7\x52\xa0\x72\x1f\x68\x8d\xb0\xdb\xa5\xd3\xde\xc6\x5b\x5b\xb7\xe9\x57\x00\x85\xe6\x1b\xd8\x42\xa9\x95\x8b\x4a\x5a\x0b\xb9\x49\x20\xa2\x4d\x23\x31\xb2\x1b\xeb\xb0\x9e\x00\xf9\x03\xe5\x0a\x9d\x60\x14\x3e\x60\x8b\x64\x02\x37\x46\x50\x39\x01\x4b\x95\x8d\x2c\x1a\x51\x5e\x43\x41\xd9\xfd\xd2\xe8\x56\xf1\x04\x9e\x95\x57\xfe\x77\x0d\x35\x35\x4b\xa1\x12\x88\xaf\x61\x17\x4e\xbb\x2c\x74\x07\x5b\xa8\x69\xd7\x47\x93\xc0\xf3\x97\x71\xd3\x1d\x2d\x5f\xc5\x4d\x07\xb4\x75\xfa\x21\x62\x59\x5e\x43\x43\x39\x17\x6a\x99\xc0\xfc\x85\xf7\x29\xb4\xe1\x68\x22\x43\xb9\x68\x6d\x02\x83\xac\x8b\x6c\x45\xb9\x5e\x27\x10\xc3\xac\xe9\xe0\x79\xd3\x81\x59\x16\xf4\x97\x78\x02\xfd\xff\x72\xfe\xeb\x9e\x4f\x35\xdb\xc7\x6e\xc5\x77\x4c\x60\x3e\x22\x13\x7b\x88\x97\x5e\xd2\x5b\x4b\x5a\xa0\x84\x2d\x70\x61\x1b\x49\x37\x09\x14\x52\xb3\xfb\xa3\xfd\x6c\xde\x74\x10\xf7\x44\x4e\x40\x67\x2f\x8e\x10\x42\x35\xad\xfb\xea\x36\x0d\x66\x0e\x3b\xf7\x6d\x72\x2a\x69\xa8\xb5\x6b\x6d\xf8\x37\xd8\xc2\x90\x9e\x59\x1c\xff\x3c\x44\x25\xbe\x87\xd8\x87\xa8\x0b\xdd\x9d\x24\xe4\xd5\x31\x1f\x49\x88\xda\x6a\x29\x38\x3c\x63\x8c\x3d\x9e\xa7\xa1\x1c\x96\xe9\x06\xc7\x29\xe8\xd9\x32\x2d\xb5\x49\xe0\xd9\xd5\xd5\xd5\xc1\x18\x8d\xd1\x06\xb6\x07\x5d\x11\xc7\xf1\x3c\x7e\x32\xd4\x4b\xca\x9c\xd0\xca\x86\x7a\xfb\x04\x45\x4e\x37\xfb\x14\x1f\x52\x58\x4a\xec\xae\xe1\xef\xd6\x3a\x51\x6e\xa2\xa1\xf1\x13\xb0\x0d\x65\x18\x15\xe8\xd6\x88\x6a\x8f\x58\xb4\xce\x69\x05\xdb\x51\xdc\x43\x85\xf6\xc1\xc7\x8f\xc7\xcb\x5a\x63\x3d\xe9\x46\x0b\xe5\xd0\x8c\x11\xbf\xae\xa8\x6c\x31\xa3\x52\xea\xb5\xcf\xfd\xa8\xf3\xe6\xfc\xb7\x39\xff\xfd\x98\x91\xd0\x89\xde\x3b\x9d\x0e\xd7\x28\x9d\xf6\xd7\x3c\xf5\x77\x29\xbf\x48\xb9\x58\x01\x93\xd4\xda\x8c\x14\xba\xeb\xef\x67\x35\xfb\x9f\xcb\x59\xcd\xbc\xdd\x76\x0b\xa2\x84\xcb\x4f\xa1\x2c\xbb\x5d\xda\xec\x81\x42\xa1\x48\xfe\x1f\x47\xa0\xf6\xde\x02\x65\x0c\xad\x05\xa7\x93\x70\xef\x0f\xee\xd3\xc6\x7b\xa0\xe2\xb0\xdb\x1d\xd1\xdf\x84\x3a\x9e\xa2\x87\xca\xf6\xe8\x07\xe5\x03\xdf\xb4\xd4\xa6\x86\x1a\x5d\xa5\x79\x46\x1a\x6d\x1d\x81\xbe\xc0\x19\xf1\x7e\x37\x61\x0d\xbb\x5d\x88\x17\x20\x0d\x7d\x0d\xa1\xaf\x49\x25\x38\x47\x45\x86\x39\x67\xd0\x36\x5a\x59\x5c\x78\x25\x81\x3e\xf9\x01\xe4\x6e\xd0\x7c\xde\x34\x78\x16\x14\x0b\x13\x6e\x21\xf8\x08\xa6\xcf\xd0\xbb\xd7\x67\xb2\xe1\xc2\x20\x73\x8b\xd6\x88\x07\x64\x7a\xc5\x97\xbb\x77\x67\x01\xf5\x25\x3a\x45\xd8\xd7\xe1\x0c\x5f\x47\xdd\x03\x5f\x2f\x39\xcb\x57\x69\xc5\xc6\xbe\x1f\xbc\xe4\x2c\x5f\xa6\x39\x2e\x58\x45\xa5\x44\xb5\x1c\x83\xdc\x6a\x8e\xb7\x7b\xcd\x0f\x80\x2d\xfa\x5e\x79\x1a\xf3\x7d\xd0\x9f\x87\x6c\x4d\xb9\x70\xfa\x1e\xd5\x18\xee\xd3\xdd\xdb\xcf\x5e\xda\x83\xf4\x49\xee\x87\x74\xa9\x4d\x46\x5a\x8b\xc6\xe7\x97\xe4\x5f\x86\x55\x3a\x0d\xea\x47\x0e\xf4\xd3\x98\x80\xe0\x27\x5e\xc3\xe1\xc7\xfd\xc9\xd1\x7b\x40\x7f\x72\x78\xaf\x98\xae\x1b\x89\x6e\x64\xef\xe5\xa5\x66\xad\x7d\x84\xdb\x7e\xd6\x93\xfc\xe3\xb0\x7a\x9a\xdb\xc1\x36\xf0\x3b\xee\x3c\x81\xd3\xfd\x98\x07\x6b\x8d\x41\xe5\xa2\x83\x7e\x60\x31\x4c\x81\xf7\x6f\x6f\xee\xf0\x9f\x56\x18\x1c\x6e\xf8\x98\x9f\xef\x0c\x92\xdf\xb4\xae\x42\xe5\x9f\xfe\x70\xbb\xbd\xf0\x9c\x14\x7a\xbb\x43\xed\xc2\x3a\xd8\xd5\x9a\xfb\x7e\x6d\x6b\x34\x82\x3d\xa4\xab\x15\x46\x4e\xd4\x18\x05\xe7\x7c\x4f\x75\x18\x40\x61\x7b\x3a\x59\x87\xc7\x65\xe8\x1d\x80\x74\x78\x1c\x7a\x2a\xb6\x2d\x6a\xe1\x8e\x14\x94\x45\xe5\x0e\x05\xe4\xa8\x36\x24\x7f\x8d\x6a\x93\x4e\x7b\xb7\x1f\x41\x09\xcf\x05\xc9\x6f\xfc\x67\x8c\x93\x4e\xb9\x58\xf9\x65\x3a\xf5\x53\x33\xbf\x18\x04\xe9\x74\x78\x21\xa6\x95\xab\x65\x7e\xf1\xef\x00\xac\x34\xe1\xf3\x40\x0a\x00\x00")
+
+func authorizeHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_authorizeHtml,
+		"authorize.html",
+	)
+}
+
+func authorizeHtml() (*asset, error) {
+	bytes, err := authorizeHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "authorize.html", size: 2624, mode: os.FileMode(511), modTime: time.Unix(1792299314, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _errorHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x51\xcd\x6e\xdb\x3c\x10\xbc\xfb\x29\xe6\x63\x2e\x5f\x01\x2b\x92\x9c\x34\x08\x64\x49\x40\xd0\x06\xe8\xa9\xed\xa1\x97\x1e\x57\xe2\xca\x22\x4a\x91\x04\x49\x39\x72\x0c\xbf\x7b\x21\xcb\x6e\xda\xa2\x90\x80\x5d\xee\xcf\xec\xec\x4e\xf9\xdf\xc7\x2f\x1f\xbe\x7d\xff\xfa\x8c\x3e\x0e\xba\x5e\x95\xb3\x81\x26\xb3\xab\x04\x1b\x31\x07\x98\x64\xbd\x02\xca\x81\x23\xa1\xed\xc9\x07\x8e\x95\x18\x63\x97\x3c\x8a\xb7\x84\xa1\x81\x2b\xb1\x57\xfc\xe2\xac\x8f\x02\xad\x35\x91\x4d\xac\xc4\x8b\x92\xb1\xaf\x24\xef\x55\xcb\xc9\xf9\xb1\x86\x32\x2a\x2a\xd2\x49\x68\x49\x73\x95\x2f\x30\x51\x45\xcd\xf5\xd3\x18\x7b\xeb\xd5\x2b\x45\x65\x0d\xd8\x7b\xeb\xcb\x74\x49\xcd\x45\x21\x1e\x16\x0f\x68\xac\x3c\xe0\x88\xce\x9a\x98\x74\x34\x28\x7d\x28\x90\x90\x73\x9a\x93\x70\x08\x91\x87\x35\xc4\x27\xd6\x7b\x8e\xaa\x25\x7c\xe6\x91\xc5\x1a\x4f\x5e\x91\x5e\x23\x90\x09\x49\x60\xaf\xba\x2d\x1a\x6a\x7f\xec\xbc\x1d\x8d\x2c\x70\xd3\xbd\x9f\xbf\x2d\x06\xf2\x3b\x65\x0a\x64\x5b\x9c\xce\xd3\x6e\x1b\x3b\xe1\x88\x81\xa6\x65\x89\x02\x77\x0f\x99\x9b\xde\x2a\x1f\x33\x37\x81\xc6\x68\xff\x46\xec\xba\x2d\x1c\x49\xa9\xcc\xae\xc0\xe6\x7e\xee\x69\xac\x97\xec\x13\x4f\x52\x8d\xa1\xc0\x25\x36\x25\xa1\x27\x69\x5f\x0a\x64\xc8\xdd\x84\x3b\x37\xc1\xef\x1a\xfa\x3f\x5b\x63\xf9\x6f\x37\xef\xae\x7c\xfa\xfc\xba\x7b\x50\xaf\x5c\x60\xf3\x07\x99\x6c\x86\x78\x70\xd3\x2f\xf6\xe7\x43\xe2\x88\xd6\x6a\xeb\x0b\xdc\x34\x59\x96\x6d\xb2\xed\xef\x08\xf9\xfd\xb5\xbe\x4c\x2f\x67\x2e\xd3\x45\xfd\x72\xbe\x75\xbd\x2a\xa5\xda\xa3\xd5\x14\x42\x25\x1a\x3b\x2d\xb2\xf5\xf9\xbf\x35\xeb\xf3\x73\xda\x5d\x1b\xce\x0c\x44\x7d\x3c\xe2\xf6\x79\x76\x71\x3a\x95\xa9\xab\x57\x65\x2a\xd5\x7e\x36\x97\x19\x69\x1f\x07\x5d\xaf\x7e\x0e\x00\x14\x78\x58\xad\x99\x02\x00\x00")
+
+func errorHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_errorHtml,
+		"error.html",
+	)
+}
+
+func errorHtml() (*asset, error) {
+	bytes, err := errorHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "error.html", size: 665, mode: os.FileMode(511), modTime: time.Unix(1792299328, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"authorize.html": authorizeHtml,
+	"error.html": errorHtml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"authorize.html": &bintree{authorizeHtml, map[string]*bintree{}},
+	"error.html": &bintree{errorHtml, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
@@ -0,0 +1,21 @@
+package admin
+
+import (
+	userapp "github.com/yusufsyaifudin/go-jwt-login-example/internal/app/user"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/passwordpolicy"
+)
+
+type HandlerConfig struct {
+	DB             db.Query
+	PasswordHasher userapp.PasswordHasher // hash the password when admin resets it
+	PasswordPolicy passwordpolicy.Policy
+}
+
+func NewAdminHandler(db db.Query) *HandlerConfig {
+	return &HandlerConfig{
+		DB:             db,
+		PasswordHasher: userapp.NewArgon2idHasher(userapp.DefaultArgon2idParams),
+		PasswordPolicy: passwordpolicy.DefaultPolicy,
+	}
+}
@@ -0,0 +1,433 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	userapp "github.com/yusufsyaifudin/go-jwt-login-example/internal/app/user"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// likeEscaper escapes wildcard character of LIKE pattern, so search is always a plain substring
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+/**
+ * @apiDefine AdminRole
+ * Only user with admin role can access this end-point, otherwise it returns 403.
+ */
+
+/**
+ * @api {get} /admin/users List Users
+ * @apiVersion 1.0.0
+ * @apiName List Users
+ * @apiGroup Admin
+ *
+ * @apiDescription List all users, ordered by id.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Query string) {String} [q] Search username, name or email which contains this text, case insensitive
+ * @apiParam (Query string) {Number} [page=1] Page number, start from 1
+ * @apiParam (Query string) {Number{1-100}} [per_page=20] Number of user in one page
+ */
+func (handler *HandlerConfig) ListUsersHandler(ctx context.Context, req http.Request) http.Response {
+	query := req.RawRequest().URL.Query()
+
+	page, err := queryInt(query.Get("page"), 1)
+	if err != nil || page < 1 {
+		return errorResponse(400, "page must be a positive number")
+	}
+
+	perPage, err := queryInt(query.Get("per_page"), defaultPerPage)
+	if err != nil || perPage < 1 || perPage > maxPerPage {
+		return errorResponse(400, fmt.Sprintf("per_page must be a number between 1 and %d", maxPerPage))
+	}
+
+	search := "%" + likeEscaper.Replace(strings.TrimSpace(query.Get("q"))) + "%"
+
+	var sqlCountUsers = `
+		SELECT count(*) AS count FROM users WHERE username ILIKE ? OR name ILIKE ? OR email ILIKE ?;
+	`
+
+	total := &struct {
+		Count int64
+	}{}
+
+	if err := handler.DB.Raw(ctx, total, sqlCountUsers, search, search, search); err != nil {
+		return errorResponse(422, fmt.Sprintf("fail counting users: %s", err.Error()))
+	}
+
+	var sqlListUsers = `
+		SELECT * FROM users WHERE username ILIKE ? OR name ILIKE ? OR email ILIKE ? ORDER BY id LIMIT ? OFFSET ?;
+	`
+
+	var users []model.User
+	if err := handler.DB.Raw(ctx, &users, sqlListUsers, search, search, search, perPage, (page-1)*perPage); err != nil {
+		return errorResponse(422, fmt.Sprintf("fail getting users: %s", err.Error()))
+	}
+
+	data := make([]map[string]interface{}, 0, len(users))
+	for i := range users {
+		data = append(data, userResponse(&users[i]))
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"users": data,
+		"pagination": map[string]interface{}{
+			"page":     page,
+			"per_page": perPage,
+			"total":    total.Count,
+		},
+	})
+}
+
+/**
+ * @api {get} /admin/users/:id Get User
+ * @apiVersion 1.0.0
+ * @apiName Get User
+ * @apiGroup Admin
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Url parameter) {Number} id User id
+ */
+func (handler *HandlerConfig) GetUserHandler(ctx context.Context, req http.Request) http.Response {
+	user, errResp := handler.findUser(ctx, req)
+	if errResp != nil {
+		return errResp
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"user": userResponse(user),
+	})
+}
+
+/**
+ * @api {patch} /admin/users/:id Update User
+ * @apiVersion 1.0.0
+ * @apiName Update User
+ * @apiGroup Admin
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Url parameter) {Number} id User id
+ * @apiParam (Request body) {String} name New name of this user
+ */
+func (handler *HandlerConfig) UpdateUserHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Name string `json:"name" form:"name"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return errorResponse(500, fmt.Sprintf("fail when binding the payload: %s", err.Error()))
+	}
+
+	if strings.TrimSpace(form.Name) == "" {
+		return errorResponse(400, "name cannot be empty")
+	}
+
+	user, errResp := handler.findUser(ctx, req)
+	if errResp != nil {
+		return errResp
+	}
+
+	var sqlUpdateName = `
+		UPDATE users SET name = ?, updated_at = now() WHERE id = ? RETURNING *;
+	`
+
+	if err := handler.DB.Raw(ctx, user, sqlUpdateName, form.Name, user.ID); err != nil {
+		return errorResponse(422, fmt.Sprintf("fail updating user: %s", err.Error()))
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"user": userResponse(user),
+	})
+}
+
+/**
+ * @api {post} /admin/users/:id/password Reset User Password
+ * @apiVersion 1.0.0
+ * @apiName Reset User Password
+ * @apiGroup Admin
+ *
+ * @apiDescription Replace the password of this user. All access tokens and refresh tokens of this user are revoked, so user must login again.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Url parameter) {Number} id User id
+ * @apiParam (Request body) {String} password New password of this user
+ */
+func (handler *HandlerConfig) ResetUserPasswordHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Password string `json:"password" form:"password"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return errorResponse(500, fmt.Sprintf("fail when binding the payload: %s", err.Error()))
+	}
+
+	if strings.TrimSpace(form.Password) == "" {
+		return errorResponse(400, "password cannot be empty")
+	}
+
+	user, errResp := handler.findUser(ctx, req)
+	if errResp != nil {
+		return errResp
+	}
+
+	if errResp := userapp.ValidatePassword(handler.PasswordPolicy, form.Password, user.Username); errResp != nil {
+		return errResp
+	}
+
+	passwordHash, err := handler.PasswordHasher.Hash(form.Password)
+	if err != nil {
+		return errorResponse(422, fmt.Sprintf("fail when hashing password: %s", err.Error()))
+	}
+
+	var sqlUpdatePassword = `
+		UPDATE users SET password = ?, token_version = token_version + 1, updated_at = now() WHERE id = ? RETURNING *;
+	`
+
+	// the old refresh tokens are revoked in the same transaction, so they cannot outlive the old password
+	err = handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		if err := tx.Raw(ctx, user, sqlUpdatePassword, passwordHash, user.ID); err != nil {
+			return fmt.Errorf("fail updating password: %s", err.Error())
+		}
+
+		if err := handler.revokeRefreshTokens(ctx, tx, user); err != nil {
+			return fmt.Errorf("fail revoking refresh token: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errorResponse(422, err.Error())
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"user": userResponse(user),
+	})
+}
+
+/**
+ * @api {post} /admin/users/:id/disable Disable User
+ * @apiVersion 1.0.0
+ * @apiName Disable User
+ * @apiGroup Admin
+ *
+ * @apiDescription Disabled user cannot login, and its access token and refresh token are rejected immediately.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Url parameter) {Number} id User id
+ */
+func (handler *HandlerConfig) DisableUserHandler(ctx context.Context, req http.Request) http.Response {
+	user, errResp := handler.findUser(ctx, req)
+	if errResp != nil {
+		return errResp
+	}
+
+	if user.ID == req.User().ID {
+		return errorResponse(422, "cannot disable your own account")
+	}
+
+	var sqlDisableUser = `
+		UPDATE users SET disabled_at = COALESCE(disabled_at, now()), updated_at = now() WHERE id = ? RETURNING *;
+	`
+
+	// the refresh tokens are revoked in the same transaction, so disabled user cannot keep any of them
+	err := handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		if err := tx.Raw(ctx, user, sqlDisableUser, user.ID); err != nil {
+			return fmt.Errorf("fail disabling user: %s", err.Error())
+		}
+
+		if err := handler.revokeRefreshTokens(ctx, tx, user); err != nil {
+			return fmt.Errorf("fail revoking refresh token: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errorResponse(422, err.Error())
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"user": userResponse(user),
+	})
+}
+
+/**
+ * @api {post} /admin/users/:id/enable Enable User
+ * @apiVersion 1.0.0
+ * @apiName Enable User
+ * @apiGroup Admin
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Url parameter) {Number} id User id
+ */
+func (handler *HandlerConfig) EnableUserHandler(ctx context.Context, req http.Request) http.Response {
+	user, errResp := handler.findUser(ctx, req)
+	if errResp != nil {
+		return errResp
+	}
+
+	var sqlEnableUser = `
+		UPDATE users SET disabled_at = NULL, updated_at = now() WHERE id = ? RETURNING *;
+	`
+
+	if err := handler.DB.Raw(ctx, user, sqlEnableUser, user.ID); err != nil {
+		return errorResponse(422, fmt.Sprintf("fail enabling user: %s", err.Error()))
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"user": userResponse(user),
+	})
+}
+
+/**
+ * @api {post} /admin/users/:id/mfa/reset Reset User MFA
+ * @apiVersion 1.0.0
+ * @apiName Reset User MFA
+ * @apiGroup Admin
+ *
+ * @apiDescription Disable two-factor authentication of this user, when user loses both the authenticator app and backup codes.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Url parameter) {Number} id User id
+ */
+func (handler *HandlerConfig) ResetUserMFAHandler(ctx context.Context, req http.Request) http.Response {
+	user, errResp := handler.findUser(ctx, req)
+	if errResp != nil {
+		return errResp
+	}
+
+	var sqlDisableTOTP = `
+		UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = now() WHERE id = ? RETURNING *;
+	`
+
+	// the backup codes are deleted in the same transaction, so they cannot be used after the secret is removed
+	err := handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		if err := tx.Raw(ctx, user, sqlDisableTOTP, user.ID); err != nil {
+			return fmt.Errorf("fail disabling two-factor authentication: %s", err.Error())
+		}
+
+		if err := tx.Exec(ctx, "DELETE FROM mfa_backup_codes WHERE user_id = ?;", user.ID); err != nil {
+			return fmt.Errorf("fail deleting backup codes: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errorResponse(422, err.Error())
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"user": userResponse(user),
+	})
+}
+
+/**
+ * @api {delete} /admin/users/:id Delete User
+ * @apiVersion 1.0.0
+ * @apiName Delete User
+ * @apiGroup Admin
+ *
+ * @apiDescription Delete this user permanently, including its tokens and roles.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiUse AdminRole
+ * @apiParam (Url parameter) {Number} id User id
+ */
+func (handler *HandlerConfig) DeleteUserHandler(ctx context.Context, req http.Request) http.Response {
+	user, errResp := handler.findUser(ctx, req)
+	if errResp != nil {
+		return errResp
+	}
+
+	if user.ID == req.User().ID {
+		return errorResponse(422, "cannot delete your own account")
+	}
+
+	// refresh tokens, revoked tokens, authorization codes and roles of this user are deleted by ON DELETE CASCADE
+	if err := handler.DB.Exec(ctx, "DELETE FROM users WHERE id = ?;", user.ID); err != nil {
+		return errorResponse(422, fmt.Sprintf("fail deleting user: %s", err.Error()))
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message": "user deleted",
+	})
+}
+
+// findUser gets the user by id in url parameter
+func (handler *HandlerConfig) findUser(ctx context.Context, req http.Request) (user *model.User, errResp http.Response) {
+	id, err := strconv.ParseInt(req.GetParam("id"), 10, 64)
+	if err != nil || id < 1 {
+		return nil, errorResponse(400, "id must be a positive number")
+	}
+
+	user = &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE id = ? LIMIT 1", id)
+	if user == nil || user.ID == 0 {
+		return nil, errorResponse(404, "user not found")
+	}
+
+	return user, nil
+}
+
+// revokeRefreshTokens revokes all refresh tokens of this user using query, so user must login again.
+// Pass the transaction when other changes of the user must be saved together.
+func (handler *HandlerConfig) revokeRefreshTokens(ctx context.Context, query db.Query, user *model.User) error {
+	var sqlRevokeRefreshTokens = `
+		UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = ? AND revoked_at IS NULL;
+	`
+
+	return query.Exec(ctx, sqlRevokeRefreshTokens, user.ID)
+}
+
+// queryInt parses the query string value as number, or returns defaultValue when it is empty
+func queryInt(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// userResponse is the user data returned by admin end-point, password hash is never returned.
+func userResponse(user *model.User) map[string]interface{} {
+	var disabledAt interface{}
+	if user.DisabledAt != nil {
+		disabledAt = user.DisabledAt.Unix()
+	}
+
+	return map[string]interface{}{
+		"id":             user.ID,
+		"name":           user.Name,
+		"username":       user.Username,
+		"email":          user.Email,
+		"email_verified": user.EmailVerifiedAt != nil,
+		"mfa_enabled":    user.TOTPEnabledAt != nil,
+		"disabled_at":    disabledAt,
+		"registered_at":  user.CreatedAt.Unix(),
+		"updated_at":     user.UpdatedAt.Unix(),
+	}
+}
+
+func errorResponse(statusCode int, message string) http.Response {
+	return http.NewJsonResponse(statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/mail"
+)
+
+const emailVerificationLifetime = 24 * time.Hour
+
+/**
+ * @api {post} /user/email/verify Verify Email
+ * @apiVersion 1.0.0
+ * @apiName Verify Email
+ * @apiGroup User
+ *
+ * @apiDescription Verify user email using token from the verification email. The token is valid for 24 hours and can only be used once.
+ *
+ * @apiParam (Request body) {String} token Token from the verification link
+ */
+func (handler *HandlerConfig) VerifyEmailHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Token string `json:"token" form:"token"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	if strings.TrimSpace(form.Token) == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "token cannot be empty",
+			},
+		})
+	}
+
+	// Mark token as used. The condition in WHERE make sure the token can only be used once.
+	var sqlUseEmailVerification = `
+		UPDATE email_verifications SET used_at = now() WHERE token_hash = ? AND used_at IS NULL AND expired_at > now() RETURNING *;
+	`
+
+	emailVerification := &model.EmailVerification{}
+	err := handler.DB.Raw(ctx, emailVerification, sqlUseEmailVerification, HashToken(form.Token))
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail updating verification token: %s", err.Error()),
+			},
+		})
+	}
+
+	if emailVerification.ID == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "verification token is not valid, already used or expired",
+			},
+		})
+	}
+
+	// token sent to the old address cannot verify the new one
+	var sqlVerifyEmail = `
+		UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()), updated_at = now() WHERE id = ? AND email = ? RETURNING *;
+	`
+
+	user := &model.User{}
+	err = handler.DB.Raw(ctx, user, sqlVerifyEmail, emailVerification.UserID, emailVerification.Email)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail verifying email: %s", err.Error()),
+			},
+		})
+	}
+
+	if user.ID == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "email of this user is already changed",
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message": "email verified",
+	})
+}
+
+/**
+ * @api {post} /user/email/verify/resend Resend Verification Email
+ * @apiVersion 1.0.0
+ * @apiName Resend Verification Email
+ * @apiGroup User
+ *
+ * @apiDescription Send the verification email again, the previous link cannot be used anymore.
+ * It doesn't need access token, since unverified user may not be able to login.
+ * The response is always success, so this end-point cannot be used to find out whether the email is registered.
+ *
+ * @apiParam (Request body) {String} email Email of registered user
+ */
+func (handler *HandlerConfig) ResendEmailVerificationHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Email string `json:"email" form:"email"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	email, err := normalizeEmail(form.Email)
+	if err != nil || email == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "email is not valid",
+			},
+		})
+	}
+
+	user := &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE email = ? LIMIT 1", email)
+	if user != nil && user.ID != 0 && user.EmailVerifiedAt == nil && user.DisabledAt == nil {
+		if err := handler.sendEmailVerification(ctx, user); err != nil {
+			return http.NewJsonResponse(422, map[string]interface{}{
+				"error": map[string]interface{}{
+					"message": fmt.Sprintf("fail sending verification email: %s", err.Error()),
+				},
+			})
+		}
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message": "if the email is registered and not verified yet, the verification link is sent to that email",
+	})
+}
+
+// sendEmailVerification creates new verification token for the current email of this user and sends it.
+func (handler *HandlerConfig) sendEmailVerification(ctx context.Context, user *model.User) error {
+	token, err := GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	// only the latest link can be used
+	var sqlInsertEmailVerification = `
+		WITH invalidated AS (
+			UPDATE email_verifications SET used_at = now() WHERE user_id = ? AND used_at IS NULL
+		)
+		INSERT INTO email_verifications (user_id, email, token_hash, expired_at) VALUES (?, ?, ?, ?);
+	`
+
+	err = handler.DB.Exec(ctx, sqlInsertEmailVerification, user.ID, user.ID, user.Email, HashToken(token), time.Now().Add(emailVerificationLifetime))
+	if err != nil {
+		return err
+	}
+
+	handler.sendMailInBackground(&mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen this link to verify your email, it is valid for 24 hours:\n%s\n\n"+
+			"If you didn't register, ignore this email.\n", user.Name, linkWithToken(handler.VerifyEmailURL, token)),
+	})
+
+	return nil
+}
+
+// sendMailInBackground sends the email without waiting, so the response time doesn't tell whether the email is registered.
+// The error is only logged.
+func (handler *HandlerConfig) sendMailInBackground(message *mail.Message) {
+	go func() {
+		if err := handler.Mailer.Send(message); err != nil {
+			logger.Error().Err(err).Str("subject", message.Subject).Msg("fail sending email")
+		}
+	}()
+}
+
+// linkWithToken returns the page url with the token in query string
+func linkWithToken(pageURL, token string) string {
+	link, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL + "?token=" + url.QueryEscape(token)
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
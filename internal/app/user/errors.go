@@ -0,0 +1,24 @@
+package user
+
+import "github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+
+// errors of user api, the code is part of the api so don't change it
+var (
+	errInvalidToken       = http.NewAPIError(401, "invalid_token", "access token is not valid")
+	errTokenRevoked       = http.NewAPIError(401, "token_revoked", "access token is revoked, please login again")
+	errInvalidCredentials = http.NewAPIError(401, "invalid_credentials", "wrong username or password")
+	errUserDisabled       = http.NewAPIError(403, "user_disabled", "user is disabled")
+	errEmailNotVerified   = http.NewAPIError(403, "email_not_verified", "please verify your email before login")
+	errUsernameTaken      = http.NewAPIError(409, "username_taken", "user with this username already registered")
+	errEmailTaken         = http.NewAPIError(409, "email_taken", "user with this email already registered")
+	errPasswordPolicy     = http.NewAPIError(422, "password_policy", "password does not meet the password policy")
+)
+
+// requiredField returns the detail of empty field, for ErrValidation
+func requiredField(field string) http.FieldError {
+	return http.FieldError{
+		Field:   field,
+		Code:    "required",
+		Message: field + " cannot be empty",
+	}
+}
@@ -1,14 +1,45 @@
 package user
 
 import (
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
 	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
 	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/lockout"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/mail"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/passwordpolicy"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/webauthn"
 )
 
+var logger = log.With().Str("pkg", "user").Logger()
+
 type HandlerConfig struct {
-	ServerSecretKey string
-	DB              db.Query
-	Auth            auth.Auth
+	ServerSecretKey  string
+	DB               db.Query
+	Auth             auth.Auth
+	Revocation       *TokenRevocation
+	LoginLockout     *LoginLockout
+	PasswordHasher   PasswordHasher // hash new password, hash of other algorithm is upgraded when user logs in
+	PasswordPolicy   passwordpolicy.Policy
+	Issuer           string // iss claim of ID token, url of this server
+	Audience         string // aud claim of ID token, client id of our frontend
+	Mailer           mail.Mailer
+	ResetPasswordURL string // page in our frontend to enter the new password, the token is added as query string
+	VerifyEmailURL   string // page in our frontend to verify the email, the token is added as query string
+	WebAuthn         webauthn.Config
+
+	// when true, email is required in register and user cannot login until the email is verified
+	RequireVerifiedEmail bool
+
+	dummyPasswordHashOnce sync.Once
+	dummyPasswordHash     string // created by PasswordHasher on the first login of unknown username
+}
+
+// mustVerifyEmail returns true when this user cannot login since the email is not verified yet
+func (handler *HandlerConfig) mustVerifyEmail(user *model.User) bool {
+	return handler.RequireVerifiedEmail && user.EmailVerifiedAt == nil
 }
 
 func NewUserHandler(serverSecretKey string, db db.Query, auth auth.Auth) *HandlerConfig {
@@ -16,5 +47,10 @@ func NewUserHandler(serverSecretKey string, db db.Query, auth auth.Auth) *Handle
 		ServerSecretKey: serverSecretKey,
 		DB:              db,
 		Auth:            auth,
+		Revocation:      NewTokenRevocation(db),
+		LoginLockout:    NewLoginLockout(lockout.NewMemoryStore()),
+		PasswordHasher:  NewArgon2idHasher(DefaultArgon2idParams),
+		PasswordPolicy:  passwordpolicy.DefaultPolicy,
+		Mailer:          mail.NewLogMailer(),
 	}
 }
@@ -0,0 +1,180 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/lockout"
+)
+
+// usernameLockoutPolicy limits wrong password or code for one username, from any ip address
+var usernameLockoutPolicy = lockout.Policy{
+	FreeAttempts:    3,
+	Delay:           time.Second,
+	MaxFailures:     10,
+	LockoutDuration: 15 * time.Minute,
+}
+
+// ipLockoutPolicy limits wrong password from one ip address for any username.
+// It is higher than username policy, since many users can be behind the same ip address.
+var ipLockoutPolicy = lockout.Policy{
+	FreeAttempts:    20,
+	Delay:           time.Second,
+	MaxFailures:     100,
+	LockoutDuration: 15 * time.Minute,
+}
+
+// LoginLockout tracks failed login by username and by client ip
+type LoginLockout struct {
+	username *lockout.Limiter
+	ip       *lockout.Limiter
+}
+
+// NewLoginLockout creates brute-force protection for login using the store, see lockout.NewMemoryStore and lockout.NewPostgresStore.
+func NewLoginLockout(store lockout.Store) *LoginLockout {
+	return &LoginLockout{
+		username: lockout.NewLimiter(store, usernameLockoutPolicy),
+		ip:       lockout.NewLimiter(store, ipLockoutPolicy),
+	}
+}
+
+// loginAttempt is one login of a username from a client ip
+type loginAttempt struct {
+	usernameKey string
+	ipKey       string
+}
+
+func newLoginAttempt(req http.Request, username string) loginAttempt {
+	return loginAttempt{
+		usernameKey: "login:username:" + username,
+		ipKey:       "login:ip:" + req.ClientIP(),
+	}
+}
+
+// reserveLoginAttempt counts the attempt as failed login before the password or code is verified, so concurrent
+// requests cannot all be checked before any of them is counted. It returns status code 423 when the username is locked,
+// or 429 when the client must wait before trying again, and then the attempt is not counted.
+// It is checked before the password, so the response doesn't tell whether the password is correct.
+// The attempt stays counted when the password or code is wrong, call releaseLoginAttempt or loginSucceeded when it is correct.
+func (handler *HandlerConfig) reserveLoginAttempt(attempt loginAttempt) (statusCode int, retryAfter time.Duration, err error) {
+	now := time.Now()
+
+	usernameStatus, err := handler.LoginLockout.username.Reserve(attempt.usernameKey, now)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case usernameStatus.Locked:
+		return 423, usernameStatus.RetryAfter, nil
+	case !usernameStatus.Allowed():
+		return 429, usernameStatus.RetryAfter, nil
+	}
+
+	ipStatus, err := handler.LoginLockout.ip.Reserve(attempt.ipKey, now)
+	if err != nil || !ipStatus.Allowed() {
+		// the username is already reserved, give it back since this attempt is not made
+		if releaseErr := handler.LoginLockout.username.Release(attempt.usernameKey); releaseErr != nil {
+			logger.Error().Err(releaseErr).Msg("fail releasing login attempt of the username")
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	if !ipStatus.Allowed() {
+		return 429, ipStatus.RetryAfter, nil
+	}
+
+	return 0, 0, nil
+}
+
+// releaseLoginAttempt uncounts the attempt when the password is correct, but the login is not finished yet,
+// for example the code is still needed or the user is disabled.
+func (handler *HandlerConfig) releaseLoginAttempt(attempt loginAttempt) {
+	if err := handler.LoginLockout.username.Release(attempt.usernameKey); err != nil {
+		logger.Error().Err(err).Msg("fail releasing login attempt of the username")
+	}
+
+	if err := handler.LoginLockout.ip.Release(attempt.ipKey); err != nil {
+		logger.Error().Err(err).Msg("fail releasing login attempt of the ip address")
+	}
+}
+
+// loginSucceeded forgets the failures of the username. For the ip address only this attempt is uncounted,
+// otherwise attacker can reset its failures by logging in to their own account.
+func (handler *HandlerConfig) loginSucceeded(attempt loginAttempt) {
+	if err := handler.LoginLockout.username.Reset(attempt.usernameKey); err != nil {
+		logger.Error().Err(err).Msg("fail resetting failed login of the username")
+	}
+
+	if err := handler.LoginLockout.ip.Release(attempt.ipKey); err != nil {
+		logger.Error().Err(err).Msg("fail releasing login attempt of the ip address")
+	}
+}
+
+// checkPasswordWithLockout verifies the password of the logged in user, for example before changing the password.
+// It applies the same brute-force protection as login, so a stolen access token cannot be used to guess the password.
+// errResp is not nil when the attempt is rejected or cannot be checked, otherwise ok tells whether the password is correct.
+func (handler *HandlerConfig) checkPasswordWithLockout(req http.Request, user *model.User, password string) (ok bool, errResp http.Response) {
+	attempt := newLoginAttempt(req, user.Username)
+	statusCode, retryAfter, err := handler.reserveLoginAttempt(attempt)
+	if err != nil {
+		return false, lockoutErrorResponse(err)
+	}
+
+	if statusCode != 0 {
+		return false, loginLockedResponse(statusCode, retryAfter)
+	}
+
+	// the attempt is already counted by reserveLoginAttempt
+	if !CheckPasswordHash(password, user.Password) {
+		return false, nil
+	}
+
+	handler.loginSucceeded(attempt)
+	return true, nil
+}
+
+// loginLockedError is returned by authenticatePassword when reserveLoginAttempt rejects the login
+type loginLockedError struct {
+	statusCode int
+	retryAfter time.Duration
+}
+
+func (err *loginLockedError) Error() string {
+	return "too many failed login, please try again later"
+}
+
+// loginLockedResponse is returned by the api when reserveLoginAttempt rejects the login
+func loginLockedResponse(statusCode int, retryAfter time.Duration) http.Response {
+	code, message := "too_many_attempts", "too many failed login, please try again later"
+	if statusCode == 423 {
+		code, message = "account_locked", "account is locked because of too many failed login, please try again later"
+	}
+
+	resp := http.NewJsonResponse(statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":        code,
+			"message":     message,
+			"retry_after": retryAfterSeconds(retryAfter),
+		},
+	})
+	resp.Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(retryAfter), 10))
+	return resp
+}
+
+// lockoutErrorResponse is returned when the failed login cannot be checked
+func lockoutErrorResponse(err error) http.Response {
+	return http.ErrInternal.WithMessage(fmt.Sprintf("fail checking failed login: %s", err.Error())).Response()
+}
+
+// retryAfterSeconds rounds up, so client doesn't retry too early
+func retryAfterSeconds(retryAfter time.Duration) int64 {
+	return int64(math.Ceil(retryAfter.Seconds()))
+}
@@ -3,11 +3,9 @@ package user
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
-	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
 	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
 )
 
@@ -17,85 +15,136 @@ import (
  * @apiName Login
  * @apiGroup User
  *
- * @apiDescription User login
+ * @apiDescription User login. When user enables two-factor authentication, the tokens are not returned yet.
+ * The response contains `mfa_required: true` and `mfa_pending_token`, send it with the code to /user/login/mfa.
+ *
+ * After 3 failed login, the next login of the same username must wait 1 second, doubled on each failure,
+ * and the response is 429 with Retry-After header. After 10 failed login, the username is locked for 15 minutes
+ * and the response is 423. Failed login from the same ip address is limited too, for any username.
  *
  * @apiParam (Request body) {String} username Username of registered user
  * @apiParam (Request body) {String} password User password
+ * @apiParam (Request body) {String} [nonce] OpenID Connect nonce, it will be copied into the ID token
  */
 func (handler *HandlerConfig) LoginUserHandler(ctx context.Context, req http.Request) http.Response {
 	form := &struct {
-		Username string `json:"username" form:"username"`
-		Password string `json:"password" form:"password"`
+		Username string `json:"username" form:"username" validate:"required"`
+		Password string `json:"password" form:"password" validate:"required"`
+		Nonce    string `json:"nonce" form:"nonce"`
 	}{}
 
-	if err := req.Bind(form); err != nil {
-		return http.NewJsonResponse(500, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
-			},
-		})
+	if err := req.ValidatedBind(form); err != nil {
+		return http.NewErrorResponse(err)
+	}
+
+	user, _, err := handler.authenticatePassword(ctx, req, form.Username, form.Password)
+	if err != nil {
+		switch err := err.(type) {
+		case *loginLockedError:
+			return loginLockedResponse(err.statusCode, err.retryAfter)
+		case *http.APIError:
+			return err.Response()
+		default:
+			return lockoutErrorResponse(err)
+		}
 	}
 
-	if strings.TrimSpace(form.Username) == "" {
-		return http.NewJsonResponse(400, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "username cannot be empty",
-			},
-		})
+	// the password is correct, but user still needs to enter the code from authenticator app
+	if user.TOTPEnabledAt != nil {
+		return handler.mfaPendingResponse(ctx, user, form.Nonce)
 	}
 
-	if strings.TrimSpace(form.Password) == "" {
-		return http.NewJsonResponse(400, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "password cannot be empty",
-			},
-		})
+	return handler.loginResponse(ctx, user, form.Nonce)
+}
+
+// authenticatePassword checks the username and password with brute-force protection. Login api and OAuth authorize page
+// use it, so both apply the same rules. The error is *loginLockedError, or *http.APIError when the password is wrong
+// or user cannot login, other error means the failed login cannot be checked.
+//
+// When the user doesn't enable TOTP, the login succeeds here. Otherwise, the caller must verify the second factor
+// using verifyLoginSecondFactor with the returned attempt.
+func (handler *HandlerConfig) authenticatePassword(ctx context.Context, req http.Request, username, password string) (user *model.User, attempt loginAttempt, err error) {
+	attempt = newLoginAttempt(req, username)
+	statusCode, retryAfter, err := handler.reserveLoginAttempt(attempt)
+	if err != nil {
+		return
+	}
+
+	if statusCode != 0 {
+		err = &loginLockedError{statusCode: statusCode, retryAfter: retryAfter}
+		return
 	}
 
 	// check user in database
-	user := &model.User{}
-	handler.DB.Raw(user, "SELECT * FROM users WHERE username = ? LIMIT 1", form.Username)
+	user = &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE username = ? LIMIT 1", username)
+	// unknown username gets the same response as wrong password, so the api doesn't tell which usernames are registered
+	// the attempt is already counted by reserveLoginAttempt
 	if user == nil || user.ID == 0 {
-		return http.NewJsonResponse(404, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "user not found",
-			},
-		})
+		handler.verifyDummyPassword(password)
+		return nil, attempt, errInvalidCredentials
+	}
+
+	if !CheckPasswordHash(password, user.Password) {
+		return nil, attempt, errInvalidCredentials
+	}
+
+	handler.upgradePasswordHash(ctx, user, password)
+
+	if user.DisabledAt != nil {
+		handler.releaseLoginAttempt(attempt)
+		return nil, attempt, errUserDisabled
 	}
 
-	if !CheckPasswordHash(form.Password, user.Password) {
-		return http.NewJsonResponse(401, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "wrong password",
-			},
-		})
+	if handler.mustVerifyEmail(user) {
+		handler.releaseLoginAttempt(attempt)
+		return nil, attempt, errEmailNotVerified
 	}
 
-	// if found, then check hashing password
-	tokenPayload := &auth.Payload{
-		ID:        fmt.Sprintf("%d", user.ID),
-		Username:  user.Username,
-		IssuedAt:  time.Now().Unix(),
-		NotBefore: time.Now().Unix(),
-		ExpiredAt: time.Now().Add(5 * time.Hour).Unix(),
+	if user.TOTPEnabledAt != nil {
+		// the code is counted again when it is verified
+		handler.releaseLoginAttempt(attempt)
+		return
 	}
 
-	accessToken, err := handler.Auth.GenerateToken(tokenPayload, handler.ServerSecretKey)
+	handler.loginSucceeded(attempt)
+	return
+}
+
+// loginResponse returns the tokens after user is authenticated
+func (handler *HandlerConfig) loginResponse(ctx context.Context, user *model.User, nonce string) http.Response {
+	accessToken, err := handler.generateAccessToken(ctx, user, nil)
 	if err != nil {
-		return http.NewJsonResponse(422, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": fmt.Sprintf("fail generating access token: %s", err.Error()),
-			},
-		})
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail generating access token: %s", err.Error())).Response()
 	}
 
-	return http.NewJsonResponse(200, map[string]interface{}{
-		"access_token": accessToken,
+	idToken, err := handler.generateIDToken(user, handler.Audience, nonce, time.Now())
+	if err != nil {
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail generating id token: %s", err.Error())).Response()
+	}
+
+	refreshToken, err := handler.generateRefreshToken(ctx, user, "", nil)
+	if err != nil {
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail generating refresh token: %s", err.Error())).Response()
+	}
+
+	body := map[string]interface{}{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
 		"user": map[string]interface{}{
-			"id":            user.ID,
-			"name":          user.Name,
-			"username":      user.Username,
-			"registered_at": user.CreatedAt.Unix(),
+			"id":             user.ID,
+			"name":           user.Name,
+			"username":       user.Username,
+			"email":          user.Email,
+			"email_verified": user.EmailVerifiedAt != nil,
+			"registered_at":  user.CreatedAt.Unix(),
 		},
-	})
+	}
+
+	// only when the server signs the token using asymmetric key
+	if idToken != "" {
+		body["id_token"] = idToken
+	}
+
+	return http.NewJsonResponse(200, body)
 }
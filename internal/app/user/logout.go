@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+// tokenPayloadContextKey is the context key where MiddlewareAuthTokenCheck put the validated token payload.
+type tokenPayloadContextKey struct{}
+
+// TokenPayloadFromContext returns the access token payload of current request.
+// It returns nil when the handler is not wrapped with MiddlewareAuthTokenCheck.
+func TokenPayloadFromContext(ctx context.Context) *auth.Payload {
+	payload, _ := ctx.Value(tokenPayloadContextKey{}).(*auth.Payload)
+	return payload
+}
+
+/**
+ * @api {post} /user/logout Logout
+ * @apiVersion 1.0.0
+ * @apiName Logout
+ * @apiGroup User
+ *
+ * @apiDescription Revoke the access token used in this request, so it cannot be used anymore even it is not expired yet.
+ * When refresh token is sent, all refresh token from the same login is revoked too.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiParam (Request body) {String} [refresh_token] Refresh token which is also revoked
+ */
+func (handler *HandlerConfig) LogoutUserHandler(ctx context.Context, req http.Request) http.Response {
+	user := req.User()
+	tokenPayload := TokenPayloadFromContext(ctx)
+	if tokenPayload == nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "access token payload is not found in this request",
+			},
+		})
+	}
+
+	form := &struct {
+		RefreshToken string `json:"refresh_token" form:"refresh_token"`
+	}{}
+
+	// body is optional here, client may only send the authorization header
+	if req.RawRequest().ContentLength != 0 {
+		if err := req.Bind(form); err != nil {
+			return http.NewJsonResponse(500, map[string]interface{}{
+				"error": map[string]interface{}{
+					"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+				},
+			})
+		}
+	}
+
+	err := handler.Revocation.Revoke(ctx, tokenPayload.JTI, user.ID, time.Unix(tokenPayload.ExpiredAt, 0))
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail revoking access token: %s", err.Error()),
+			},
+		})
+	}
+
+	if strings.TrimSpace(form.RefreshToken) != "" {
+		// only revoke refresh token owned by this user
+		refreshToken := &model.RefreshToken{}
+		handler.DB.Raw(ctx, refreshToken, "SELECT * FROM refresh_tokens WHERE token_hash = ? AND user_id = ? LIMIT 1", HashToken(form.RefreshToken), user.ID)
+		if refreshToken.ID != 0 {
+			err = handler.revokeRefreshTokenFamily(ctx, refreshToken.FamilyID)
+			if err != nil {
+				return http.NewJsonResponse(422, map[string]interface{}{
+					"error": map[string]interface{}{
+						"message": fmt.Sprintf("fail revoking refresh token: %s", err.Error()),
+					},
+				})
+			}
+		}
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message": "logout success",
+	})
+}
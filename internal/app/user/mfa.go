@@ -0,0 +1,529 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/totp"
+)
+
+const (
+	mfaChallengeLifetime    = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5
+	mfaBackupCodeCount      = 10
+)
+
+/**
+ * @api {post} /user/mfa/totp/setup Setup TOTP
+ * @apiVersion 1.0.0
+ * @apiName Setup TOTP
+ * @apiGroup MFA
+ *
+ * @apiDescription Start two-factor authentication enrollment. Scan the QR code (PNG in data uri) or enter the secret
+ * in authenticator app, then send the first code to /user/mfa/totp/confirm. Calling this again replaces the secret.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ */
+func (handler *HandlerConfig) TOTPSetupHandler(ctx context.Context, req http.Request) http.Response {
+	user := req.User()
+	if user.TOTPEnabledAt != nil {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "two-factor authentication is already enabled",
+			},
+		})
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating secret: %s", err.Error()),
+			},
+		})
+	}
+
+	encryptedSecret, err := handler.encryptTOTPSecret(secret)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail encrypting secret: %s", err.Error()),
+			},
+		})
+	}
+
+	var sqlSetSecret = `
+		UPDATE users SET totp_secret = ?, updated_at = now() WHERE id = ? AND totp_enabled_at IS NULL RETURNING *;
+	`
+
+	err = handler.DB.Raw(ctx, user, sqlSetSecret, encryptedSecret, user.ID)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail saving secret: %s", err.Error()),
+			},
+		})
+	}
+
+	uri := totp.URI(handler.totpIssuer(), user.Username, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, 256)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating qr code: %s", err.Error()),
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"secret":      secret,
+		"otpauth_uri": uri,
+		"qr_code":     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	})
+}
+
+/**
+ * @api {post} /user/mfa/totp/confirm Confirm TOTP
+ * @apiVersion 1.0.0
+ * @apiName Confirm TOTP
+ * @apiGroup MFA
+ *
+ * @apiDescription Enable two-factor authentication using the first code from authenticator app.
+ * The response contains backup codes, which is only shown once. Each backup code can replace the code once.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiParam (Request body) {String} code 6 digits code from authenticator app
+ */
+func (handler *HandlerConfig) TOTPConfirmHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Code string `json:"code" form:"code"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	user := req.User()
+	if user.TOTPEnabledAt != nil || user.TOTPSecret == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "two-factor authentication is already enabled or not set up yet",
+			},
+		})
+	}
+
+	secret, err := handler.decryptTOTPSecret(user.TOTPSecret)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": err.Error(),
+			},
+		})
+	}
+
+	counter, ok := totp.Validate(secret, strings.TrimSpace(form.Code), time.Now())
+	if !ok {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "wrong code",
+			},
+		})
+	}
+
+	var sqlEnableTOTP = `
+		UPDATE users SET totp_enabled_at = now(), totp_last_counter = ?, updated_at = now() WHERE id = ? AND totp_enabled_at IS NULL RETURNING *;
+	`
+
+	// the backup codes are saved in the same transaction, so TOTP is never enabled without them
+	enabled := &model.User{}
+	var backupCodes []string
+	err = handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		if err := tx.Raw(ctx, enabled, sqlEnableTOTP, counter, user.ID); err != nil {
+			return fmt.Errorf("fail enabling two-factor authentication: %s", err.Error())
+		}
+
+		if enabled.ID == 0 {
+			return nil
+		}
+
+		codes, err := handler.generateBackupCodes(ctx, tx, enabled)
+		if err != nil {
+			return fmt.Errorf("fail generating backup codes: %s", err.Error())
+		}
+
+		backupCodes = codes
+		return nil
+	})
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": err.Error(),
+			},
+		})
+	}
+
+	// other request enables it in the meantime
+	if enabled.ID == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "two-factor authentication is already enabled or not set up yet",
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message":      "two-factor authentication is enabled",
+		"backup_codes": backupCodes,
+	})
+}
+
+/**
+ * @api {post} /user/mfa/totp/disable Disable TOTP
+ * @apiVersion 1.0.0
+ * @apiName Disable TOTP
+ * @apiGroup MFA
+ *
+ * @apiDescription Disable two-factor authentication and remove all backup codes.
+ * Both the password and the current code are needed, so stolen access token or password alone cannot remove it.
+ * Wrong password is counted as failed login of this user, see Login.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiParam (Request body) {String} password Current password of this user
+ * @apiParam (Request body) {String} code 6 digits code from authenticator app, or one of backup codes
+ */
+func (handler *HandlerConfig) TOTPDisableHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Password string `json:"password" form:"password"`
+		Code     string `json:"code" form:"code"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	user := req.User()
+	ok, errResp := handler.checkPasswordWithLockout(req, user, form.Password)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !ok {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "wrong password",
+			},
+		})
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "two-factor authentication is not enabled",
+			},
+		})
+	}
+
+	ok, err := handler.verifySecondFactor(ctx, user, form.Code)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail verifying code: %s", err.Error()),
+			},
+		})
+	}
+
+	if !ok {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "wrong code",
+			},
+		})
+	}
+
+	if err := handler.disableTOTP(ctx, user.ID); err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail disabling two-factor authentication: %s", err.Error()),
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message": "two-factor authentication is disabled",
+	})
+}
+
+/**
+ * @api {post} /user/login/mfa Login MFA
+ * @apiVersion 1.0.0
+ * @apiName Login MFA
+ * @apiGroup MFA
+ *
+ * @apiDescription Second step of login when two-factor authentication is enabled. The response is the same as login.
+ * The mfa_pending_token is valid for 5 minutes and accepts 5 wrong codes at most.
+ *
+ * @apiParam (Request body) {String} mfa_pending_token Token from login response
+ * @apiParam (Request body) {String} code 6 digits code from authenticator app, or one of backup codes
+ */
+func (handler *HandlerConfig) LoginMFAHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		MFAPendingToken string `json:"mfa_pending_token" form:"mfa_pending_token"`
+		Code            string `json:"code" form:"code"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	if strings.TrimSpace(form.MFAPendingToken) == "" || strings.TrimSpace(form.Code) == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "mfa_pending_token and code cannot be empty",
+			},
+		})
+	}
+
+	// count the attempt first, so guessing the code is limited even when requests are sent at the same time
+	var sqlAttemptChallenge = `
+		UPDATE mfa_challenges SET attempts = attempts + 1
+		WHERE token_hash = ? AND used_at IS NULL AND expired_at > now() AND attempts < ? RETURNING *;
+	`
+
+	challenge := &model.MFAChallenge{}
+	err := handler.DB.Raw(ctx, challenge, sqlAttemptChallenge, HashToken(form.MFAPendingToken), mfaChallengeMaxAttempts)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail updating mfa_pending_token: %s", err.Error()),
+			},
+		})
+	}
+
+	if challenge.ID == 0 {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "mfa_pending_token is not valid, expired or has too many attempts, please login again",
+			},
+		})
+	}
+
+	user := &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE id = ? LIMIT 1", challenge.UserID)
+	if user == nil || user.ID == 0 || user.DisabledAt != nil {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "user is not found or disabled",
+			},
+		})
+	}
+
+	// wrong code is counted as failed login, so user cannot guess the code by logging in again and again
+	ok, err := handler.verifyLoginSecondFactor(ctx, user, newLoginAttempt(req, user.Username), form.Code)
+	if lockedErr, isLocked := err.(*loginLockedError); isLocked {
+		return loginLockedResponse(lockedErr.statusCode, lockedErr.retryAfter)
+	}
+
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail verifying code: %s", err.Error()),
+			},
+		})
+	}
+
+	if !ok {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "wrong code",
+			},
+		})
+	}
+
+	var sqlUseChallenge = `
+		UPDATE mfa_challenges SET used_at = now() WHERE id = ? AND used_at IS NULL RETURNING *;
+	`
+
+	usedChallenge := &model.MFAChallenge{}
+	err = handler.DB.Raw(ctx, usedChallenge, sqlUseChallenge, challenge.ID)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail updating mfa_pending_token: %s", err.Error()),
+			},
+		})
+	}
+
+	if usedChallenge.ID == 0 {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "mfa_pending_token is already used, please login again",
+			},
+		})
+	}
+
+	return handler.loginResponse(ctx, user, challenge.Nonce)
+}
+
+// verifyLoginSecondFactor verifies the code in login with the same brute-force protection as the password.
+// The error is *loginLockedError when the attempt must wait, and the login succeeds when the code is correct.
+func (handler *HandlerConfig) verifyLoginSecondFactor(ctx context.Context, user *model.User, attempt loginAttempt, code string) (ok bool, err error) {
+	statusCode, retryAfter, err := handler.reserveLoginAttempt(attempt)
+	if err != nil {
+		return
+	}
+
+	if statusCode != 0 {
+		return false, &loginLockedError{statusCode: statusCode, retryAfter: retryAfter}
+	}
+
+	// wrong code stays counted
+	if ok, err = handler.verifySecondFactor(ctx, user, code); ok {
+		handler.loginSucceeded(attempt)
+	}
+
+	return
+}
+
+// disableTOTP removes the secret and backup codes of this user in one transaction
+func (handler *HandlerConfig) disableTOTP(ctx context.Context, userID int64) error {
+	var sqlDisableTOTP = `
+		UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = now() WHERE id = ?;
+	`
+
+	return handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		if err := tx.Exec(ctx, sqlDisableTOTP, userID); err != nil {
+			return err
+		}
+
+		return tx.Exec(ctx, "DELETE FROM mfa_backup_codes WHERE user_id = ?;", userID)
+	})
+}
+
+// mfaPendingResponse is returned when the password is correct, but the code is still needed
+func (handler *HandlerConfig) mfaPendingResponse(ctx context.Context, user *model.User, nonce string) http.Response {
+	token, err := GenerateRandomToken(32)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating mfa_pending_token: %s", err.Error()),
+			},
+		})
+	}
+
+	var sqlInsertChallenge = `
+		INSERT INTO mfa_challenges (user_id, token_hash, nonce, expired_at) VALUES (?, ?, ?, ?);
+	`
+
+	err = handler.DB.Exec(ctx, sqlInsertChallenge, user.ID, HashToken(token), nonce, time.Now().Add(mfaChallengeLifetime))
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail saving mfa_pending_token: %s", err.Error()),
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"mfa_required":      true,
+		"mfa_pending_token": token,
+		"expires_in":        int64(mfaChallengeLifetime / time.Second),
+	})
+}
+
+// verifySecondFactor checks the code from authenticator app or one of backup codes.
+// Accepted code cannot be used again.
+func (handler *HandlerConfig) verifySecondFactor(ctx context.Context, user *model.User, code string) (ok bool, err error) {
+	code = strings.ToLower(strings.Replace(strings.TrimSpace(code), "-", "", -1))
+
+	if len(code) == totp.Digits {
+		secret, err := handler.decryptTOTPSecret(user.TOTPSecret)
+		if err != nil {
+			return false, err
+		}
+
+		counter, valid := totp.Validate(secret, code, time.Now())
+		if !valid {
+			return false, nil
+		}
+
+		// the WHERE condition rejects the code which is already used, since its counter is not newer
+		var sqlUseCounter = `
+			UPDATE users SET totp_last_counter = ? WHERE id = ? AND totp_last_counter < ? RETURNING *;
+		`
+
+		updated := &model.User{}
+		if err = handler.DB.Raw(ctx, updated, sqlUseCounter, counter, user.ID, counter); err != nil {
+			return false, err
+		}
+
+		return updated.ID != 0, nil
+	}
+
+	var sqlUseBackupCode = `
+		UPDATE mfa_backup_codes SET used_at = now() WHERE user_id = ? AND code_hash = ? AND used_at IS NULL RETURNING *;
+	`
+
+	backupCode := &model.MFABackupCode{}
+	if err = handler.DB.Raw(ctx, backupCode, sqlUseBackupCode, user.ID, HashToken(code)); err != nil {
+		return false, err
+	}
+
+	return backupCode.ID != 0, nil
+}
+
+// generateBackupCodes replaces all backup codes of this user using tx, and returns the plain codes.
+func (handler *HandlerConfig) generateBackupCodes(ctx context.Context, tx db.Query, user *model.User) (codes []string, err error) {
+	if err = tx.Exec(ctx, "DELETE FROM mfa_backup_codes WHERE user_id = ?;", user.ID); err != nil {
+		return
+	}
+
+	for i := 0; i < mfaBackupCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err = rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		// 10 hex characters, shown as xxxxx-xxxxx so it is easier to type
+		code := hex.EncodeToString(b)
+		err = tx.Exec(ctx, "INSERT INTO mfa_backup_codes (user_id, code_hash) VALUES (?, ?);", user.ID, HashToken(code))
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+
+	return
+}
+
+// totpIssuer is the name shown in authenticator app, the host of this server
+func (handler *HandlerConfig) totpIssuer() string {
+	issuer, err := url.Parse(handler.Issuer)
+	if err != nil || issuer.Host == "" {
+		return handler.Issuer
+	}
+
+	return issuer.Host
+}
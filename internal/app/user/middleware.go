@@ -13,6 +13,9 @@ import (
 
 /**
  * @apiDefine MiddlewareAuthTokenCheck
+ * Access token from client_credentials grant is accepted too, in that case the request has service client instead of user.
+ * Access token issued to OAuth client using authorization code grant is rejected, it can only be used in /userinfo.
+ *
  * @apiHeader {String} Authorization Must using Bearer access token.
  * @apiHeaderExample {json} Header-Example:
  *     {
@@ -25,6 +28,16 @@ import (
  *     }
  */
 func (handler *HandlerConfig) MiddlewareAuthTokenCheck(next http.Handler) http.Handler {
+	return handler.authTokenCheck(next, false)
+}
+
+// MiddlewareOAuthTokenCheck is MiddlewareAuthTokenCheck which also accepts user token issued to OAuth client.
+// Only use it for the end-points which are meant for third-party client, like /userinfo.
+func (handler *HandlerConfig) MiddlewareOAuthTokenCheck(next http.Handler) http.Handler {
+	return handler.authTokenCheck(next, true)
+}
+
+func (handler *HandlerConfig) authTokenCheck(next http.Handler, allowOAuthClient bool) http.Handler {
 	return func(parent context.Context, req http.Request) http.Response {
 		var accessToken string
 
@@ -45,11 +58,7 @@ func (handler *HandlerConfig) MiddlewareAuthTokenCheck(next http.Handler) http.H
 		if accessToken == "" {
 			body, err := ioutil.ReadAll(req.RawRequest().Body)
 			if err != nil {
-				return http.NewJsonResponse(500, map[string]interface{}{
-					"error": map[string]interface{}{
-						"message": fmt.Sprintf("%s: %s", "error when reading the request body", err.Error()),
-					},
-				})
+				return http.ErrInternal.WithMessage(fmt.Sprintf("%s: %s", "error when reading the request body", err.Error())).Response()
 			}
 
 			// copy twice to make sure body can be re-binding after middleware
@@ -72,30 +81,76 @@ func (handler *HandlerConfig) MiddlewareAuthTokenCheck(next http.Handler) http.H
 
 		jwtPayload, err := handler.Auth.ValidateToken(accessToken, handler.ServerSecretKey)
 		if err != nil {
-			return http.NewJsonResponse(403, map[string]interface{}{
-				"error": map[string]interface{}{
-					"message": fmt.Sprintf("%s: %s", "error when validating access token", err.Error()),
-				},
-			})
+			return errInvalidToken.WithMessage(fmt.Sprintf("%s: %s", "error when validating access token", err.Error())).Response()
+		}
+
+		// token without jti cannot be revoked, so we don't accept it
+		if strings.TrimSpace(jwtPayload.JTI) == "" {
+			return errInvalidToken.WithMessage("error when validating access token: jti must contains value").Response()
+		}
+
+		revoked, err := handler.Revocation.IsRevoked(parent, jwtPayload.JTI)
+		if err != nil {
+			return http.ErrInternal.WithMessage(fmt.Sprintf("%s: %s", "error when checking token revocation", err.Error())).Response()
+		}
+
+		if revoked {
+			return errTokenRevoked.Response()
+		}
+
+		// the client only gets what the user allows in the consent page, not our first-party end-points
+		if jwtPayload.AuthorizedParty != "" && !allowOAuthClient {
+			return http.ErrForbidden.WithMessage("access token issued to OAuth client cannot be used in this end-point").Response()
 		}
 
-		// jwtPayload.ID
-		sqlGetUser := `SELECT * FROM users WHERE id = ? LIMIT 1;`
-
-		// check user in database
-		user := &model.User{}
-		handler.DB.Raw(user, sqlGetUser, jwtPayload.ID)
-		if user == nil || user.ID == 0 {
-			return http.NewJsonResponse(401, map[string]interface{}{
-				"error": map[string]interface{}{
-					"message": "cannot continue this request since user is not found with this token",
-				},
-			})
+		// token issued using client_credentials grant belongs to service client, not user
+		if jwtPayload.ClientID != "" {
+			sqlGetClient := `SELECT * FROM service_clients WHERE client_id = ? LIMIT 1;`
+
+			// check service client in database
+			client := &model.ServiceClient{}
+			handler.DB.Raw(parent, client, sqlGetClient, jwtPayload.ClientID)
+			if client == nil || client.ID == 0 || client.DisabledAt != nil {
+				return errInvalidToken.WithMessage("cannot continue this request since client is not found or disabled with this token").Response()
+			}
+
+			req.SetClient(client)
+		} else {
+			// jwtPayload.ID
+			sqlGetUser := `SELECT * FROM users WHERE id = ? LIMIT 1;`
+
+			// check user in database
+			user := &model.User{}
+			handler.DB.Raw(parent, user, sqlGetUser, jwtPayload.ID)
+			if user == nil || user.ID == 0 {
+				return errInvalidToken.WithMessage("cannot continue this request since user is not found with this token").Response()
+			}
+
+			if user.DisabledAt != nil {
+				return errUserDisabled.WithMessage("cannot continue this request since user is disabled").Response()
+			}
+
+			// all token issued before the password is changed is rejected
+			if jwtPayload.Version != user.TokenVersion {
+				return errTokenRevoked.WithMessage("access token is revoked since the password is changed, please login again").Response()
+			}
+
+			req.SetUser(user)
 		}
 
-		req.SetUser(user)
+		// run the wrapped handler, with token payload so handler like logout can read it
+		return next(context.WithValue(parent, tokenPayloadContextKey{}, jwtPayload), req)
+	}
+}
+
+// MiddlewareRequireUser rejects the request made using service client token.
+// It must be chained after MiddlewareAuthTokenCheck, for handler which needs req.User().
+func (handler *HandlerConfig) MiddlewareRequireUser(next http.Handler) http.Handler {
+	return func(ctx context.Context, req http.Request) http.Response {
+		if req.User() == nil {
+			return http.ErrForbidden.WithMessage("this endpoint can only be accessed using user access token").Response()
+		}
 
-		// run the wrapped handler
-		return next(parent, req)
+		return next(ctx, req)
 	}
 }
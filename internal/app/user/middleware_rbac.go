@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+// RequireRole returns middleware which only continues the request when the access token has at least one of the roles.
+// It must be chained after MiddlewareAuthTokenCheck, since the roles are read from the token payload:
+//
+//	http.ChainMiddleware(userHandler.MiddlewareAuthTokenCheck, user.RequireRole("admin"))
+func RequireRole(roles ...string) http.Middleware {
+	return func(next http.Handler) http.Handler {
+		return func(ctx context.Context, req http.Request) http.Response {
+			tokenPayload := TokenPayloadFromContext(ctx)
+			if tokenPayload != nil {
+				for _, role := range roles {
+					if tokenPayload.HasRole(role) {
+						return next(ctx, req)
+					}
+				}
+			}
+
+			return forbiddenResponse(fmt.Sprintf("one of these roles is required: %s", strings.Join(roles, ", ")))
+		}
+	}
+}
+
+// RequirePermission returns middleware which only continues the request when the access token has all of the permissions.
+// Like RequireRole, it must be chained after MiddlewareAuthTokenCheck.
+func RequirePermission(permissions ...string) http.Middleware {
+	return func(next http.Handler) http.Handler {
+		return func(ctx context.Context, req http.Request) http.Response {
+			tokenPayload := TokenPayloadFromContext(ctx)
+			if tokenPayload == nil {
+				return forbiddenResponse(fmt.Sprintf("these permissions are required: %s", strings.Join(permissions, ", ")))
+			}
+
+			for _, permission := range permissions {
+				if !tokenPayload.HasPermission(permission) {
+					return forbiddenResponse(fmt.Sprintf("permission %s is required", permission))
+				}
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+func forbiddenResponse(message string) http.Response {
+	return http.ErrForbidden.WithMessage(message).Response()
+}
@@ -0,0 +1,322 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"html/template"
+	stdhttp "net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/assets/pages"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+const authorizationCodeLifetime = 5 * time.Minute
+
+// authorizeCSRFCookie keeps the csrf token of the last rendered page, it must be the same as the submitted csrf_token
+const authorizeCSRFCookie = "oauth_authorize_csrf"
+
+// scope which client can ask in authorization request
+const (
+	scopeOpenID  = "openid"  // ID token is issued too
+	scopeProfile = "profile" // name and username in userinfo
+	scopeRoles   = "roles"   // roles and permissions of the user are put in the access token
+)
+
+var supportedScopes = []string{scopeOpenID, scopeProfile, scopeRoles}
+
+var authorizePage = template.Must(template.New("authorize").Parse(string(pages.MustAsset("authorize.html"))))
+var authorizeErrorPage = template.Must(template.New("error").Parse(string(pages.MustAsset("error.html"))))
+
+// authorizeForm is the parameter of authorization request, see RFC 6749 section 4.1.1 and RFC 7636 section 4.3.
+// The same parameters are sent again as hidden input when user submits the login and consent page.
+type authorizeForm struct {
+	ResponseType        string `json:"response_type" form:"response_type"`
+	ClientID            string `json:"client_id" form:"client_id"`
+	RedirectURI         string `json:"redirect_uri" form:"redirect_uri"`
+	Scope               string `json:"scope" form:"scope"`
+	State               string `json:"state" form:"state"`
+	Nonce               string `json:"nonce" form:"nonce"`
+	CodeChallenge       string `json:"code_challenge" form:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method" form:"code_challenge_method"`
+
+	// only sent when user submits the login and consent page
+	Username  string `json:"username" form:"username"`
+	Password  string `json:"password" form:"password"`
+	Consent   string `json:"consent" form:"consent"`
+	Code      string `json:"code" form:"code"` // two-factor authentication code, only when user enables it
+	CSRFToken string `json:"csrf_token" form:"csrf_token"`
+
+	mfaRequired bool // show the code input in the page
+}
+
+/**
+ * @api {get} /oauth/authorize Authorize
+ * @apiVersion 1.0.0
+ * @apiName Authorize
+ * @apiGroup OAuth
+ *
+ * @apiDescription OAuth 2.0 authorization endpoint for authorization code grant with PKCE (RFC 7636).
+ * It shows the login and consent page. After user allows the request, user is redirected to `redirect_uri` with `code` and `state`.
+ * This endpoint is not prefixed with /api/v1.
+ *
+ * @apiParam (Query string) {String="code"} response_type Must be code
+ * @apiParam (Query string) {String} client_id Registered client id
+ * @apiParam (Query string) {String} [redirect_uri] One of registered redirect uri, can be omitted when client only has one
+ * @apiParam (Query string) {String} [scope] Space separated scope: openid to get ID token, profile, and roles to put roles and permissions of the user in the access token
+ * @apiParam (Query string) {String} [state] Opaque value returned back to client
+ * @apiParam (Query string) {String} [nonce] Copied into the ID token
+ * @apiParam (Query string) {String} code_challenge Base64url encoded sha256 of code verifier
+ * @apiParam (Query string) {String="S256"} code_challenge_method Must be S256
+ */
+func (handler *HandlerConfig) AuthorizeHandler(ctx context.Context, req http.Request) http.Response {
+	form := &authorizeForm{}
+	if err := req.Bind(form); err != nil {
+		return renderAuthorizeError(400, fmt.Sprintf("fail when binding the payload: %s", err.Error()))
+	}
+
+	client, _, errResp := handler.validateAuthorizeRequest(ctx, form)
+	if errResp != nil {
+		return errResp
+	}
+
+	return handler.renderAuthorizePage(200, client, form, "")
+}
+
+/**
+ * @api {post} /oauth/authorize Authorize Submit
+ * @apiVersion 1.0.0
+ * @apiName Authorize Submit
+ * @apiGroup OAuth
+ *
+ * @apiDescription Submitted by the login and consent page. All parameters of GET /oauth/authorize is sent again as form value.
+ *
+ * @apiParam (Request body) {String} username Username of registered user
+ * @apiParam (Request body) {String} password User password
+ * @apiParam (Request body) {String="allow","deny"} consent Whether user allows the client or not
+ * @apiParam (Request body) {String} [code] Code from authenticator app or backup code, when user enables two-factor authentication
+ * @apiParam (Request body) {String} csrf_token Token in the page, it must match the cookie set when the page is shown
+ */
+func (handler *HandlerConfig) AuthorizeSubmitHandler(ctx context.Context, req http.Request) http.Response {
+	form := &authorizeForm{}
+	if err := req.Bind(form); err != nil {
+		return renderAuthorizeError(400, fmt.Sprintf("fail when binding the payload: %s", err.Error()))
+	}
+
+	// other site cannot read the cookie or the page, so it cannot submit the form on behalf of the user
+	cookie, err := req.RawRequest().Cookie(authorizeCSRFCookie)
+	if err != nil || form.CSRFToken == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(form.CSRFToken)) != 1 {
+		return renderAuthorizeError(403, "the page is expired or not submitted from this site, please start the login again")
+	}
+
+	client, redirectURI, errResp := handler.validateAuthorizeRequest(ctx, form)
+	if errResp != nil {
+		return errResp
+	}
+
+	if form.Consent != "allow" {
+		return redirectWithParams(redirectURI, map[string]string{
+			"error":             "access_denied",
+			"error_description": "user denied the request",
+			"state":             form.State,
+		})
+	}
+
+	user, attempt, err := handler.authenticatePassword(ctx, req, form.Username, form.Password)
+	if err != nil {
+		switch err := err.(type) {
+		case *loginLockedError:
+			resp := handler.renderAuthorizePage(err.statusCode, client, form, err.Error())
+			resp.Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(err.retryAfter), 10))
+			return resp
+		case *http.APIError:
+			return handler.renderAuthorizePage(err.Status, client, form, err.Message)
+		default:
+			return renderAuthorizeError(500, fmt.Sprintf("fail checking failed login: %s", err.Error()))
+		}
+	}
+
+	if user.TOTPEnabledAt != nil {
+		form.mfaRequired = true
+		if strings.TrimSpace(form.Code) == "" {
+			return handler.renderAuthorizePage(401, client, form, "enter the code from your authenticator app")
+		}
+
+		ok, err := handler.verifyLoginSecondFactor(ctx, user, attempt, form.Code)
+		if lockedErr, isLocked := err.(*loginLockedError); isLocked {
+			resp := handler.renderAuthorizePage(lockedErr.statusCode, client, form, lockedErr.Error())
+			resp.Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(lockedErr.retryAfter), 10))
+			return resp
+		}
+
+		if err != nil {
+			return renderAuthorizeError(500, fmt.Sprintf("fail verifying code: %s", err.Error()))
+		}
+
+		if !ok {
+			return handler.renderAuthorizePage(401, client, form, "wrong code")
+		}
+	}
+
+	code, err := GenerateRandomToken(32)
+	if err != nil {
+		return renderAuthorizeError(500, fmt.Sprintf("fail generating authorization code: %s", err.Error()))
+	}
+
+	var sqlInsertCode = `
+		INSERT INTO oauth_authorization_codes
+		(code_hash, client_id, user_id, redirect_uri, redirect_uri_explicit, scope, code_challenge, code_challenge_method, nonce, auth_time, expired_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	now := time.Now()
+	err = handler.DB.Exec(ctx, sqlInsertCode, HashToken(code), client.ClientID, user.ID, redirectURI, form.RedirectURI != "", form.Scope,
+		form.CodeChallenge, form.CodeChallengeMethod, form.Nonce, now, now.Add(authorizationCodeLifetime))
+	if err != nil {
+		return renderAuthorizeError(500, fmt.Sprintf("fail saving authorization code: %s", err.Error()))
+	}
+
+	return redirectWithParams(redirectURI, map[string]string{
+		"code":  code,
+		"state": form.State,
+	})
+}
+
+// validateAuthorizeRequest checks the client and redirect uri first. When one of them is not valid,
+// we must not redirect the user (RFC 6749 section 4.1.2.1), so the error page is shown.
+// Other errors are sent back to the client through redirect uri.
+func (handler *HandlerConfig) validateAuthorizeRequest(ctx context.Context, form *authorizeForm) (client *model.OAuthClient, redirectURI string, errResp http.Response) {
+	client = &model.OAuthClient{}
+	handler.DB.Raw(ctx, client, "SELECT * FROM oauth_clients WHERE client_id = ? LIMIT 1", form.ClientID)
+	if client == nil || client.ID == 0 {
+		errResp = renderAuthorizeError(400, "client is not registered")
+		return
+	}
+
+	redirectURI = form.RedirectURI
+	if redirectURI == "" {
+		registered := strings.Fields(client.RedirectURIs)
+		if len(registered) == 1 {
+			redirectURI = registered[0]
+		}
+	}
+
+	if !client.AllowRedirectURI(redirectURI) {
+		errResp = renderAuthorizeError(400, "redirect_uri is not registered for this client")
+		return
+	}
+
+	if form.ResponseType != "code" {
+		errResp = redirectWithParams(redirectURI, map[string]string{
+			"error":             "unsupported_response_type",
+			"error_description": "only response_type code is supported",
+			"state":             form.State,
+		})
+		return
+	}
+
+	for _, scope := range strings.Fields(form.Scope) {
+		if !containsString(supportedScopes, scope) {
+			errResp = redirectWithParams(redirectURI, map[string]string{
+				"error":             "invalid_scope",
+				"error_description": fmt.Sprintf("scope %s is not supported", scope),
+				"state":             form.State,
+			})
+			return
+		}
+	}
+
+	// PKCE is required, and plain method is not accepted
+	if form.CodeChallenge == "" || form.CodeChallengeMethod != "S256" {
+		errResp = redirectWithParams(redirectURI, map[string]string{
+			"error":             "invalid_request",
+			"error_description": "code_challenge with code_challenge_method S256 is required",
+			"state":             form.State,
+		})
+		return
+	}
+
+	return
+}
+
+// redirectWithParams adds params into redirect uri query string, empty param is not added.
+func redirectWithParams(redirectURI string, params map[string]string) http.Response {
+	location, err := url.Parse(redirectURI)
+	if err != nil {
+		return renderAuthorizeError(400, "redirect_uri is not valid url")
+	}
+
+	query := location.Query()
+	for key, value := range params {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	location.RawQuery = query.Encode()
+	return http.NewRedirectResponse(location.String())
+}
+
+// renderAuthorizePage shows the login and consent page. The redirect_uri is sent back as it is in the authorization request,
+// so the submitted request still tells whether the client sends it or the registered one is used.
+// Every page has new csrf token, which is put in the form and in the cookie.
+func (handler *HandlerConfig) renderAuthorizePage(statusCode int, client *model.OAuthClient, form *authorizeForm, errMessage string) http.Response {
+	csrfToken, err := GenerateRandomToken(32)
+	if err != nil {
+		return renderAuthorizeError(500, fmt.Sprintf("fail generating csrf token: %s", err.Error()))
+	}
+
+	var body bytes.Buffer
+	err = authorizePage.Execute(&body, map[string]interface{}{
+		"Action":              "/oauth/authorize",
+		"ClientName":          client.Name,
+		"ClientID":            client.ClientID,
+		"RedirectURI":         form.RedirectURI,
+		"ResponseType":        form.ResponseType,
+		"Scope":               form.Scope,
+		"State":               form.State,
+		"Nonce":               form.Nonce,
+		"CodeChallenge":       form.CodeChallenge,
+		"CodeChallengeMethod": form.CodeChallengeMethod,
+		"Username":            form.Username,
+		"MFARequired":         form.mfaRequired,
+		"Error":               errMessage,
+		"CSRFToken":           csrfToken,
+	})
+	if err != nil {
+		return renderAuthorizeError(500, fmt.Sprintf("fail rendering page: %s", err.Error()))
+	}
+
+	cookie := &stdhttp.Cookie{
+		Name:     authorizeCSRFCookie,
+		Value:    csrfToken,
+		Path:     "/oauth/authorize",
+		MaxAge:   int(time.Hour / time.Second),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(handler.Issuer, "https://"),
+		SameSite: stdhttp.SameSiteStrictMode,
+	}
+
+	resp := http.NewHtmlResponse(statusCode, body.Bytes())
+	resp.Header().Set("X-Frame-Options", "DENY")
+	resp.Header().Set("Cache-Control", "no-store")
+	resp.Header().Add("Set-Cookie", cookie.String())
+	return resp
+}
+
+func renderAuthorizeError(statusCode int, errMessage string) http.Response {
+	var body bytes.Buffer
+	authorizeErrorPage.Execute(&body, map[string]interface{}{
+		"Error": errMessage,
+	})
+
+	resp := http.NewHtmlResponse(statusCode, body.Bytes())
+	resp.Header().Set("X-Frame-Options", "DENY")
+	return resp
+}
@@ -0,0 +1,256 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+// oauthTokenForm is the parameter of token request, see RFC 6749 section 4.1.3 and 4.4.2.
+type oauthTokenForm struct {
+	GrantType    string `json:"grant_type" form:"grant_type"`
+	Code         string `json:"code" form:"code"`
+	RedirectURI  string `json:"redirect_uri" form:"redirect_uri"`
+	ClientID     string `json:"client_id" form:"client_id"`
+	ClientSecret string `json:"client_secret" form:"client_secret"`
+	CodeVerifier string `json:"code_verifier" form:"code_verifier"`
+}
+
+/**
+ * @api {post} /oauth/token Token
+ * @apiVersion 1.0.0
+ * @apiName Token
+ * @apiGroup OAuth
+ *
+ * @apiDescription OAuth 2.0 token endpoint. Exchange the authorization code with access token,
+ * or get access token for service client using client_credentials grant.
+ * The request body must be form encoded (application/x-www-form-urlencoded), and the error follows RFC 6749 section 5.2.
+ * Service client can send its credential using HTTP Basic authentication or client_id and client_secret in request body.
+ * This endpoint is not prefixed with /api/v1.
+ *
+ * @apiParam (Request body) {String="authorization_code","client_credentials"} grant_type Grant type
+ * @apiParam (Request body) {String} [code] Authorization code from redirect uri, for authorization_code grant
+ * @apiParam (Request body) {String} [redirect_uri] The same redirect uri used in authorization request, only required when it is sent in authorization request
+ * @apiParam (Request body) {String} client_id Registered client id
+ * @apiParam (Request body) {String} [client_secret] Service client secret, for client_credentials grant
+ * @apiParam (Request body) {String} [code_verifier] PKCE code verifier, for authorization_code grant
+ */
+func (handler *HandlerConfig) OAuthTokenHandler(ctx context.Context, req http.Request) http.Response {
+	form := &oauthTokenForm{}
+	if err := req.Bind(form); err != nil {
+		return oauthErrorResponse(400, "invalid_request", fmt.Sprintf("fail when binding the payload: %s", err.Error()))
+	}
+
+	switch form.GrantType {
+	case "authorization_code":
+		return handler.authorizationCodeGrant(ctx, form)
+	case "client_credentials":
+		return handler.clientCredentialsGrant(ctx, req, form)
+	default:
+		return oauthErrorResponse(400, "unsupported_grant_type", "grant_type is not supported")
+	}
+}
+
+// authorizationCodeGrant exchanges the authorization code from /oauth/authorize with user token.
+func (handler *HandlerConfig) authorizationCodeGrant(ctx context.Context, form *oauthTokenForm) http.Response {
+	if strings.TrimSpace(form.Code) == "" || strings.TrimSpace(form.CodeVerifier) == "" {
+		return oauthErrorResponse(400, "invalid_request", "code and code_verifier cannot be empty")
+	}
+
+	authorizationCode := &model.AuthorizationCode{}
+	handler.DB.Raw(ctx, authorizationCode, "SELECT * FROM oauth_authorization_codes WHERE code_hash = ? LIMIT 1", HashToken(form.Code))
+	if authorizationCode == nil || authorizationCode.ID == 0 {
+		return oauthErrorResponse(400, "invalid_grant", "authorization code is not valid")
+	}
+
+	if authorizationCode.UsedAt != nil {
+		return handler.authorizationCodeReusedResponse(ctx, authorizationCode.ID)
+	}
+
+	if !authorizationCode.ExpiredAt.After(time.Now()) {
+		return oauthErrorResponse(400, "invalid_grant", "authorization code is expired")
+	}
+
+	if authorizationCode.ClientID != form.ClientID {
+		return oauthErrorResponse(400, "invalid_grant", "client_id does not match the authorization request")
+	}
+
+	// redirect_uri is only required when it is sent in the authorization request (RFC 6749 section 4.1.3)
+	if (authorizationCode.RedirectURIExplicit || form.RedirectURI != "") && authorizationCode.RedirectURI != form.RedirectURI {
+		return oauthErrorResponse(400, "invalid_grant", "redirect_uri does not match the authorization request")
+	}
+
+	if !verifyCodeChallenge(form.CodeVerifier, authorizationCode.CodeChallenge) {
+		return oauthErrorResponse(400, "invalid_grant", "code_verifier does not match the code_challenge")
+	}
+
+	user := &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE id = ? LIMIT 1", authorizationCode.UserID)
+	if user == nil || user.ID == 0 || user.DisabledAt != nil || handler.mustVerifyEmail(user) {
+		return oauthErrorResponse(400, "invalid_grant", "user is not found, disabled or the email is not verified")
+	}
+
+	// the token is bound to the client, so it cannot call our first-party end-points
+	grant := &oauthGrant{
+		ClientID: authorizationCode.ClientID,
+		Scope:    authorizationCode.Scope,
+	}
+
+	tokenPayload, err := handler.accessTokenPayload(ctx, user, grant)
+	if err != nil {
+		return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail generating access token: %s", err.Error()))
+	}
+
+	// the refresh token family is saved in the code, so it can be revoked when the code is used again
+	familyID, err := GenerateRandomToken(16)
+	if err != nil {
+		return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail generating refresh token family: %s", err.Error()))
+	}
+
+	// authorization code can only be used once, the WHERE condition make sure only one request can win.
+	// The access token jti is saved together, so it is already known when the code is used again.
+	var sqlUseCode = `
+		UPDATE oauth_authorization_codes SET used_at = now(), family_id = ?, access_token_jti = ?, access_token_expired_at = ?
+		WHERE id = ? AND used_at IS NULL RETURNING *;
+	`
+
+	usedCode := &model.AuthorizationCode{}
+	err = handler.DB.Raw(ctx, usedCode, sqlUseCode, familyID, tokenPayload.JTI, time.Unix(tokenPayload.ExpiredAt, 0), authorizationCode.ID)
+	if err != nil {
+		return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail updating authorization code: %s", err.Error()))
+	}
+
+	if usedCode.ID == 0 {
+		return handler.authorizationCodeReusedResponse(ctx, authorizationCode.ID)
+	}
+
+	accessToken, err := handler.Auth.GenerateToken(tokenPayload, handler.ServerSecretKey)
+	if err != nil {
+		return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail generating access token: %s", err.Error()))
+	}
+
+	refreshToken, err := handler.generateRefreshToken(ctx, user, familyID, grant)
+	if err != nil {
+		return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail generating refresh token: %s", err.Error()))
+	}
+
+	tokenResponse := map[string]interface{}{
+		"access_token":  accessToken,
+		"token_type":    "Bearer",
+		"expires_in":    int64(accessTokenLifetime / time.Second),
+		"refresh_token": refreshToken,
+		"scope":         authorizationCode.Scope,
+	}
+
+	// ID token is only issued when client asks openid scope
+	if grant.hasScope(scopeOpenID) {
+		idToken, err := handler.generateIDToken(user, authorizationCode.ClientID, authorizationCode.Nonce, authorizationCode.AuthTime)
+		if err != nil {
+			return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail generating id token: %s", err.Error()))
+		}
+
+		if idToken != "" {
+			tokenResponse["id_token"] = idToken
+		}
+	}
+
+	resp := http.NewJsonResponse(200, tokenResponse)
+	resp.Header().Set("Cache-Control", "no-store")
+	resp.Header().Set("Pragma", "no-cache")
+	return resp
+}
+
+// authorizationCodeReusedResponse is called when used authorization code is sent again. The code may be stolen,
+// so the access token and refresh token issued using this code are revoked (RFC 6749 section 4.1.2).
+func (handler *HandlerConfig) authorizationCodeReusedResponse(ctx context.Context, codeID int64) http.Response {
+	// read again, the family id and jti are saved by the request which uses the code first
+	authorizationCode := &model.AuthorizationCode{}
+	handler.DB.Raw(ctx, authorizationCode, "SELECT * FROM oauth_authorization_codes WHERE id = ? LIMIT 1", codeID)
+	if authorizationCode.FamilyID != "" {
+		if err := handler.revokeRefreshTokenFamily(ctx, authorizationCode.FamilyID); err != nil {
+			return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail revoking refresh token: %s", err.Error()))
+		}
+	}
+
+	if authorizationCode.AccessTokenJTI != "" && authorizationCode.AccessTokenExpiredAt != nil {
+		err := handler.Revocation.Revoke(ctx, authorizationCode.AccessTokenJTI, authorizationCode.UserID, *authorizationCode.AccessTokenExpiredAt)
+		if err != nil {
+			return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail revoking access token: %s", err.Error()))
+		}
+	}
+
+	return oauthErrorResponse(400, "invalid_grant", "authorization code is already used, the token issued using this code is revoked")
+}
+
+// clientCredentialsGrant issues access token for service client. Refresh token is not issued (RFC 6749 section 4.4.3).
+func (handler *HandlerConfig) clientCredentialsGrant(ctx context.Context, req http.Request, form *oauthTokenForm) http.Response {
+	clientID, clientSecret := form.ClientID, form.ClientSecret
+
+	// client_secret_basic, the credential is form encoded before put in the header (RFC 6749 section 2.3.1)
+	if username, password, ok := req.RawRequest().BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(username)
+		clientSecret, _ = url.QueryUnescape(password)
+	}
+
+	if strings.TrimSpace(clientID) == "" || strings.TrimSpace(clientSecret) == "" {
+		return invalidClientResponse("client_id and client_secret cannot be empty")
+	}
+
+	client := &model.ServiceClient{}
+	handler.DB.Raw(ctx, client, "SELECT * FROM service_clients WHERE client_id = ? LIMIT 1", clientID)
+	if client == nil || client.ID == 0 || client.DisabledAt != nil || !CheckPasswordHash(clientSecret, client.SecretHash) {
+		return invalidClientResponse("client authentication failed")
+	}
+
+	accessToken, err := handler.generateClientAccessToken(client)
+	if err != nil {
+		return oauthErrorResponse(500, "server_error", fmt.Sprintf("fail generating access token: %s", err.Error()))
+	}
+
+	resp := http.NewJsonResponse(200, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int64(clientAccessTokenLifetime / time.Second),
+	})
+	resp.Header().Set("Cache-Control", "no-store")
+	resp.Header().Set("Pragma", "no-cache")
+	return resp
+}
+
+// verifyCodeChallenge checks code verifier using S256 method: BASE64URL(SHA256(code_verifier)) == code_challenge
+func verifyCodeChallenge(codeVerifier, codeChallenge string) bool {
+	// RFC 7636 section 4.1, code verifier is 43 to 128 characters
+	if len(codeVerifier) < 43 || len(codeVerifier) > 128 {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+// oauthErrorResponse returns error in the format of RFC 6749 section 5.2
+func oauthErrorResponse(statusCode int, code, description string) http.Response {
+	resp := http.NewJsonResponse(statusCode, map[string]interface{}{
+		"error":             code,
+		"error_description": description,
+	})
+	resp.Header().Set("Cache-Control", "no-store")
+	resp.Header().Set("Pragma", "no-cache")
+	return resp
+}
+
+// invalidClientResponse is returned when service client authentication fails, with the challenge for HTTP Basic authentication.
+func invalidClientResponse(description string) http.Response {
+	resp := oauthErrorResponse(401, "invalid_client", description)
+	resp.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	return resp
+}
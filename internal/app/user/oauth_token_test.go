@@ -0,0 +1,33 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Verify PKCE code challenge using S256", t, func() {
+		// example from RFC 7636 appendix B
+		codeVerifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+		codeChallenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+		convey.Convey("When code verifier is correct", func() {
+			convey.So(verifyCodeChallenge(codeVerifier, codeChallenge), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("When code verifier is different", func() {
+			convey.So(verifyCodeChallenge("x"+codeVerifier[1:], codeChallenge), convey.ShouldBeFalse)
+		})
+
+		convey.Convey("When code verifier is the challenge itself (plain method)", func() {
+			convey.So(verifyCodeChallenge(codeChallenge, codeChallenge), convey.ShouldBeFalse)
+		})
+
+		convey.Convey("When code verifier is too short", func() {
+			convey.So(verifyCodeChallenge("abc", codeChallenge), convey.ShouldBeFalse)
+		})
+	})
+}
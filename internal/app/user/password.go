@@ -0,0 +1,123 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+/**
+ * @api {post} /user/password Change Password
+ * @apiVersion 1.0.0
+ * @apiName Change Password
+ * @apiGroup User
+ *
+ * @apiDescription Change the password of current user. All access tokens and refresh tokens issued before are rejected,
+ * including the one used in this request, so the new tokens are returned to continue this session.
+ * Wrong current password is counted as failed login of this user, see Login.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiParam (Request body) {String} current_password Current password of this user
+ * @apiParam (Request body) {String} new_password The new password
+ */
+func (handler *HandlerConfig) ChangePasswordHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		CurrentPassword string `json:"current_password" form:"current_password"`
+		NewPassword     string `json:"new_password" form:"new_password"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	if strings.TrimSpace(form.NewPassword) == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "new_password cannot be empty",
+			},
+		})
+	}
+
+	user := req.User()
+	ok, errResp := handler.checkPasswordWithLockout(req, user, form.CurrentPassword)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !ok {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "wrong current password",
+			},
+		})
+	}
+
+	if errResp := ValidatePassword(handler.PasswordPolicy, form.NewPassword, user.Username); errResp != nil {
+		return errResp
+	}
+
+	passwordHash, err := handler.PasswordHasher.Hash(form.NewPassword)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when hashing password: %s", err.Error()),
+			},
+		})
+	}
+
+	// increasing token version makes all issued access token rejected in MiddlewareAuthTokenCheck
+	var sqlUpdatePassword = `
+		UPDATE users SET password = ?, token_version = token_version + 1, updated_at = now() WHERE id = ? RETURNING *;
+	`
+
+	// the old refresh tokens are revoked in the same transaction, so they cannot outlive the old password
+	err = handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		if err := tx.Raw(ctx, user, sqlUpdatePassword, passwordHash, user.ID); err != nil {
+			return fmt.Errorf("fail updating password: %s", err.Error())
+		}
+
+		if err := handler.revokeUserRefreshTokens(ctx, tx, user.ID); err != nil {
+			return fmt.Errorf("fail revoking refresh token: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": err.Error(),
+			},
+		})
+	}
+
+	accessToken, err := handler.generateAccessToken(ctx, user, nil)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating access token: %s", err.Error()),
+			},
+		})
+	}
+
+	refreshToken, err := handler.generateRefreshToken(ctx, user, "", nil)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating refresh token: %s", err.Error()),
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message":       "password changed",
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+	})
+}
@@ -0,0 +1,218 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHasher hashes the password into a string which contains the algorithm and its parameters,
+// so the hash can still be verified after the parameters are changed.
+type PasswordHasher interface {
+	Hash(password string) (hash string, err error)
+	Verify(password, hash string) bool
+
+	// Identify returns true when the hash is created by this algorithm
+	Identify(hash string) bool
+
+	// NeedsRehash returns true when the hash is created by this algorithm using different parameters
+	NeedsRehash(hash string) bool
+}
+
+// knownPasswordHashers can verify all hashes in users table, whatever hasher is used for the new password
+var knownPasswordHashers = []PasswordHasher{
+	NewBcryptHasher(bcrypt.DefaultCost),
+	NewArgon2idHasher(DefaultArgon2idParams),
+}
+
+// CheckPasswordHash will check the password using the algorithm of the hash, bcrypt or argon2id
+func CheckPasswordHash(password, hash string) bool {
+	for _, hasher := range knownPasswordHashers {
+		if hasher.Identify(hash) {
+			return hasher.Verify(password, hash)
+		}
+	}
+
+	return false
+}
+
+// upgradePasswordHash rehashes the password when the hash is created by other algorithm or old parameters.
+// It must be called after the password is verified, since it is the only time we know the plain password.
+// Failure is only logged, user can still login using the old hash.
+func (handler *HandlerConfig) upgradePasswordHash(ctx context.Context, user *model.User, password string) {
+	if handler.PasswordHasher.Identify(user.Password) && !handler.PasswordHasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	passwordHash, err := handler.PasswordHasher.Hash(password)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("fail rehashing password")
+		return
+	}
+
+	// the WHERE condition makes sure the password is not changed by other request in the meantime
+	var sqlUpgradePassword = `
+		UPDATE users SET password = ? WHERE id = ? AND password = ?;
+	`
+
+	if err = handler.DB.Exec(ctx, sqlUpgradePassword, passwordHash, user.ID, user.Password); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("fail saving rehashed password")
+		return
+	}
+
+	user.Password = passwordHash
+}
+
+// verifyDummyPassword checks the password against a hash which never matches. It is called when the username is not found,
+// so the response takes as long as a wrong password and doesn't tell which usernames are registered.
+func (handler *HandlerConfig) verifyDummyPassword(password string) {
+	handler.dummyPasswordHashOnce.Do(func() {
+		passwordHash, err := handler.PasswordHasher.Hash("dummy password of unknown username")
+		if err != nil {
+			logger.Error().Err(err).Msg("fail creating dummy password hash")
+			return
+		}
+
+		handler.dummyPasswordHash = passwordHash
+	})
+
+	CheckPasswordHash(password, handler.dummyPasswordHash)
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates hasher using bcrypt, the hash is in $2a$cost$ format.
+func NewBcryptHasher(cost int) PasswordHasher {
+	return &bcryptHasher{
+		cost: cost,
+	}
+}
+
+func (hasher *bcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), hasher.cost)
+	return string(bytes), err
+}
+
+func (hasher *bcryptHasher) Verify(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func (hasher *bcryptHasher) Identify(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (hasher *bcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != hasher.cost
+}
+
+// Argon2idParams is the parameters of argon2id, see RFC 9106
+type Argon2idParams struct {
+	Memory      uint32 // in KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  int
+	KeyLength   uint32
+}
+
+// DefaultArgon2idParams uses 64 MiB memory and 3 iterations, as recommended by RFC 9106 section 4 for memory constrained environment
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+// NewArgon2idHasher creates hasher using argon2id, the hash is in PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=2$salt$key
+func NewArgon2idHasher(params Argon2idParams) PasswordHasher {
+	return &argon2idHasher{
+		params: params,
+	}
+}
+
+func (hasher *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, hasher.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	params := hasher.params
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (hasher *argon2idHasher) Verify(password, hash string) bool {
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return false
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, otherKey) == 1
+}
+
+func (hasher *argon2idHasher) Identify(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+func (hasher *argon2idHasher) NeedsRehash(hash string) bool {
+	params, salt, _, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != hasher.params.Memory || params.Iterations != hasher.params.Iterations ||
+		params.Parallelism != hasher.params.Parallelism || params.KeyLength != hasher.params.KeyLength ||
+		len(salt) != hasher.params.SaltLength
+}
+
+// decodeArgon2idHash parses the hash in PHC string format
+func decodeArgon2idHash(hash string) (params Argon2idParams, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		err = fmt.Errorf("hash is not argon2id")
+		return
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		err = fmt.Errorf("unsupported argon2 version")
+		return
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil || params.Iterations == 0 || params.Parallelism == 0 {
+		err = fmt.Errorf("argon2id parameters are not valid")
+		return
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return
+	}
+
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		err = fmt.Errorf("argon2id key is not valid")
+		return
+	}
+
+	params.SaltLength = len(salt)
+	params.KeyLength = uint32(len(key))
+	return
+}
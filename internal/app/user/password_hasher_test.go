@@ -0,0 +1,69 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// small parameters, so the test runs fast
+var testArgon2idParams = Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestPasswordHasher(t *testing.T) {
+	t.Parallel()
+
+	hashers := map[string]PasswordHasher{
+		"bcrypt":   NewBcryptHasher(bcrypt.MinCost),
+		"argon2id": NewArgon2idHasher(testArgon2idParams),
+	}
+
+	convey.Convey("Hash and verify password", t, func() {
+		for name, hasher := range hashers {
+			name, hasher := name, hasher
+			convey.Convey("When using "+name, func() {
+				hash, err := hasher.Hash("secret password")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(hasher.Identify(hash), convey.ShouldBeTrue)
+				convey.So(hasher.NeedsRehash(hash), convey.ShouldBeFalse)
+				convey.So(hasher.Verify("secret password", hash), convey.ShouldBeTrue)
+				convey.So(hasher.Verify("wrong password", hash), convey.ShouldBeFalse)
+
+				// hash from any algorithm can be checked
+				convey.So(CheckPasswordHash("secret password", hash), convey.ShouldBeTrue)
+				convey.So(CheckPasswordHash("wrong password", hash), convey.ShouldBeFalse)
+			})
+		}
+
+		convey.Convey("The parameters are written in argon2id hash", func() {
+			hash, err := hashers["argon2id"].Hash("secret password")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(hash, convey.ShouldStartWith, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+			// changing the parameters doesn't break the old hash, but it must be rehashed
+			stronger := testArgon2idParams
+			stronger.Iterations = 2
+			convey.So(NewArgon2idHasher(stronger).Verify("secret password", hash), convey.ShouldBeTrue)
+			convey.So(NewArgon2idHasher(stronger).NeedsRehash(hash), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("When bcrypt cost is changed", func() {
+			hash, err := hashers["bcrypt"].Hash("secret password")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(NewBcryptHasher(bcrypt.DefaultCost).NeedsRehash(hash), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("When the hash is not valid", func() {
+			convey.So(CheckPasswordHash("secret password", "plain text"), convey.ShouldBeFalse)
+			convey.So(CheckPasswordHash("secret password", "$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5"), convey.ShouldBeFalse)
+			convey.So(hashers["argon2id"].Identify(strings.Repeat("$", 5)), convey.ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"fmt"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/passwordpolicy"
+)
+
+// ValidatePassword checks the new password of the user against the policy, and returns error response listing every failed rule.
+// It returns nil when the password is accepted. Every path which sets the password must call it before hashing the password.
+func ValidatePassword(policy passwordpolicy.Policy, password, username string) http.Response {
+	details, err := passwordPolicyDetails(policy, password, username)
+	if err != nil {
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail checking password: %s", err.Error())).Response()
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+
+	return errPasswordPolicy.WithDetails(details...).Response()
+}
+
+// passwordPolicyDetails returns one field error of password for every rule which fails, so it can be sent with other fields.
+func passwordPolicyDetails(policy passwordpolicy.Policy, password, username string) ([]http.FieldError, error) {
+	violations, err := policy.Validate(password, username)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]http.FieldError, 0, len(violations))
+	for _, violation := range violations {
+		details = append(details, http.FieldError{
+			Field:   "password",
+			Code:    violation.Rule,
+			Message: violation.Message,
+		})
+	}
+
+	return details, nil
+}
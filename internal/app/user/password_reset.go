@@ -0,0 +1,221 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/mail"
+)
+
+const passwordResetLifetime = 1 * time.Hour
+
+/**
+ * @api {post} /user/password/forgot Forgot Password
+ * @apiVersion 1.0.0
+ * @apiName Forgot Password
+ * @apiGroup User
+ *
+ * @apiDescription Send the link to reset the password into user email. The link is valid for 1 hour and can only be used once.
+ * The response is always success, so this end-point cannot be used to find out whether the email is registered.
+ *
+ * @apiParam (Request body) {String} email Email of registered user
+ */
+func (handler *HandlerConfig) ForgotPasswordHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Email string `json:"email" form:"email"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	email, err := normalizeEmail(form.Email)
+	if err != nil || email == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "email is not valid",
+			},
+		})
+	}
+
+	successResponse := http.NewJsonResponse(200, map[string]interface{}{
+		"message": "if the email is registered, the link to reset the password is sent to that email",
+	})
+
+	user := &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE email = ? LIMIT 1", email)
+	if user == nil || user.ID == 0 || user.DisabledAt != nil {
+		return successResponse
+	}
+
+	token, err := GenerateRandomToken(32)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating reset token: %s", err.Error()),
+			},
+		})
+	}
+
+	// only the latest link can be used
+	var sqlInsertPasswordReset = `
+		WITH invalidated AS (
+			UPDATE password_resets SET used_at = now() WHERE user_id = ? AND used_at IS NULL
+		)
+		INSERT INTO password_resets (user_id, token_hash, expired_at) VALUES (?, ?, ?);
+	`
+
+	err = handler.DB.Exec(ctx, sqlInsertPasswordReset, user.ID, user.ID, HashToken(token), time.Now().Add(passwordResetLifetime))
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail saving reset token: %s", err.Error()),
+			},
+		})
+	}
+
+	handler.sendMailInBackground(&mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen this link to reset your password, it is valid for 1 hour:\n%s\n\n"+
+			"If you didn't ask to reset your password, ignore this email.\n", user.Name, linkWithToken(handler.ResetPasswordURL, token)),
+	})
+
+	return successResponse
+}
+
+/**
+ * @api {post} /user/password/reset Reset Password
+ * @apiVersion 1.0.0
+ * @apiName Reset Password
+ * @apiGroup User
+ *
+ * @apiDescription Set the new password using token from the reset password email.
+ * All access tokens and refresh tokens issued before are rejected, so user must login again.
+ *
+ * @apiParam (Request body) {String} token Token from the reset password link
+ * @apiParam (Request body) {String} new_password The new password
+ */
+func (handler *HandlerConfig) ResetPasswordHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Token       string `json:"token" form:"token"`
+		NewPassword string `json:"new_password" form:"new_password"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	if strings.TrimSpace(form.Token) == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "token cannot be empty",
+			},
+		})
+	}
+
+	if strings.TrimSpace(form.NewPassword) == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "new_password cannot be empty",
+			},
+		})
+	}
+
+	// check the password before the token is used, so user can try another password using the same link
+	var sqlGetResetUser = `
+		SELECT users.* FROM password_resets JOIN users ON users.id = password_resets.user_id WHERE password_resets.token_hash = ? LIMIT 1;
+	`
+
+	resetUser := &model.User{}
+	handler.DB.Raw(ctx, resetUser, sqlGetResetUser, HashToken(form.Token))
+	if errResp := ValidatePassword(handler.PasswordPolicy, form.NewPassword, resetUser.Username); errResp != nil {
+		return errResp
+	}
+
+	passwordHash, err := handler.PasswordHasher.Hash(form.NewPassword)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when hashing password: %s", err.Error()),
+			},
+		})
+	}
+
+	// Mark token as used. The condition in WHERE make sure the token can only be used once.
+	var sqlUsePasswordReset = `
+		UPDATE password_resets SET used_at = now() WHERE token_hash = ? AND used_at IS NULL AND expired_at > now() RETURNING *;
+	`
+
+	var sqlUpdatePassword = `
+		UPDATE users SET password = ?, token_version = token_version + 1, updated_at = now() WHERE id = ? AND disabled_at IS NULL RETURNING *;
+	`
+
+	// the token is used, and the old refresh tokens are revoked in the same transaction as the password update,
+	// so the token is still valid when the update fails, and the old refresh tokens cannot outlive the old password
+	passwordReset := &model.PasswordReset{}
+	user := &model.User{}
+	err = handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		if err := tx.Raw(ctx, passwordReset, sqlUsePasswordReset, HashToken(form.Token)); err != nil {
+			return fmt.Errorf("fail updating reset token: %s", err.Error())
+		}
+
+		if passwordReset.ID == 0 {
+			return nil
+		}
+
+		if err := tx.Raw(ctx, user, sqlUpdatePassword, passwordHash, passwordReset.UserID); err != nil {
+			return fmt.Errorf("fail updating password: %s", err.Error())
+		}
+
+		if user.ID == 0 {
+			return nil
+		}
+
+		if err := handler.revokeUserRefreshTokens(ctx, tx, user.ID); err != nil {
+			return fmt.Errorf("fail revoking refresh token: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": err.Error(),
+			},
+		})
+	}
+
+	if passwordReset.ID == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "reset token is not valid, already used or expired",
+			},
+		})
+	}
+
+	if user.ID == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "user is not found or disabled",
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message": "password changed, please login using the new password",
+	})
+}
@@ -18,13 +18,19 @@ import (
  */
 func (handler *HandlerConfig) ProfileUserHandler(ctx context.Context, req http.Request) http.Response {
 	user := req.User()
+	if user == nil {
+		return http.ErrUnauthorized.WithMessage("this endpoint can only be accessed using user access token").Response()
+	}
 
 	return http.NewJsonResponse(200, map[string]interface{}{
 		"user": map[string]interface{}{
-			"id":            user.ID,
-			"name":          user.Name,
-			"username":      user.Username,
-			"registered_at": user.CreatedAt.Unix(),
+			"id":             user.ID,
+			"name":           user.Name,
+			"username":       user.Username,
+			"email":          user.Email,
+			"email_verified": user.EmailVerifiedAt != nil,
+			"mfa_enabled":    user.TOTPEnabledAt != nil,
+			"registered_at":  user.CreatedAt.Unix(),
 		},
 	})
 }
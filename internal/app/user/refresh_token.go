@@ -0,0 +1,190 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+/**
+ * @api {post} /user/token/refresh Refresh Token
+ * @apiVersion 1.0.0
+ * @apiName Refresh Token
+ * @apiGroup User
+ *
+ * @apiDescription Exchange refresh token with the new access token and refresh token.
+ * Each refresh token can only be used once. When used refresh token is sent again,
+ * all refresh token issued from the same login will be revoked and user must login again.
+ *
+ * @apiParam (Request body) {String} refresh_token Refresh token from login, register or previous refresh
+ */
+func (handler *HandlerConfig) RefreshTokenHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		RefreshToken string `json:"refresh_token" form:"refresh_token"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	if strings.TrimSpace(form.RefreshToken) == "" {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "refresh_token cannot be empty",
+			},
+		})
+	}
+
+	refreshToken := &model.RefreshToken{}
+	handler.DB.Raw(ctx, refreshToken, "SELECT * FROM refresh_tokens WHERE token_hash = ? LIMIT 1", HashToken(form.RefreshToken))
+	if refreshToken == nil || refreshToken.ID == 0 {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "refresh token not found",
+			},
+		})
+	}
+
+	if refreshToken.RevokedAt != nil {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "refresh token is revoked",
+			},
+		})
+	}
+
+	if refreshToken.UsedAt != nil {
+		return handler.refreshTokenReusedResponse(ctx, refreshToken.FamilyID)
+	}
+
+	if !refreshToken.ExpiredAt.After(time.Now()) {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "refresh token is expired",
+			},
+		})
+	}
+
+	user := &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE id = ? LIMIT 1", refreshToken.UserID)
+	if user == nil || user.ID == 0 {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "cannot continue this request since user is not found with this token",
+			},
+		})
+	}
+
+	if user.DisabledAt != nil {
+		return http.NewJsonResponse(403, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "user is disabled",
+			},
+		})
+	}
+
+	if handler.mustVerifyEmail(user) {
+		return errEmailNotVerified.Response()
+	}
+
+	accessToken, err := handler.generateAccessToken(ctx, user, grantOfRefreshToken(refreshToken))
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating access token: %s", err.Error()),
+			},
+		})
+	}
+
+	// Mark token as used. The condition in WHERE make sure only one request can win,
+	// so two requests using the same token at the same time is also treated as reuse.
+	var sqlUseRefreshToken = `
+		UPDATE refresh_tokens SET used_at = now() WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL RETURNING *;
+	`
+
+	// the old token is only used when the new one is saved, so cancelled request doesn't log the user out
+	var newRefreshToken string
+	reused := false
+	err = handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		usedToken := &model.RefreshToken{}
+		if err := tx.Raw(ctx, usedToken, sqlUseRefreshToken, refreshToken.ID); err != nil {
+			return fmt.Errorf("fail updating refresh token: %s", err.Error())
+		}
+
+		if usedToken.ID == 0 {
+			reused = true
+			return nil
+		}
+
+		var err error
+		newRefreshToken, err = handler.generateRefreshTokenInTx(ctx, tx, user, refreshToken.FamilyID, grantOfRefreshToken(refreshToken))
+		if err != nil {
+			return fmt.Errorf("fail generating refresh token: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": err.Error(),
+			},
+		})
+	}
+
+	if reused {
+		return handler.refreshTokenReusedResponse(ctx, refreshToken.FamilyID)
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"access_token":  accessToken,
+		"refresh_token": newRefreshToken,
+	})
+}
+
+// refreshTokenReusedResponse is called when used refresh token is sent again.
+// This may because the token is stolen, so we revoke all token in the same family to force user login again.
+func (handler *HandlerConfig) refreshTokenReusedResponse(ctx context.Context, familyID string) http.Response {
+	err := handler.revokeRefreshTokenFamily(ctx, familyID)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail revoking refresh token: %s", err.Error()),
+			},
+		})
+	}
+
+	return http.NewJsonResponse(401, map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": "refresh token already used, all sessions from this login is revoked",
+		},
+	})
+}
+
+// revokeRefreshTokenFamily revokes all refresh token rotated from the same login.
+func (handler *HandlerConfig) revokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	var sqlRevokeFamily = `
+		UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = ? AND revoked_at IS NULL;
+	`
+
+	return handler.DB.Exec(ctx, sqlRevokeFamily, familyID)
+}
+
+// revokeUserRefreshTokens revokes all refresh token of this user, from all logins.
+// Pass the transaction which changes the password, so both are saved or none of them.
+func (handler *HandlerConfig) revokeUserRefreshTokens(ctx context.Context, tx db.Query, userID int64) error {
+	var sqlRevokeUserTokens = `
+		UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = ? AND revoked_at IS NULL;
+	`
+
+	return tx.Exec(ctx, sqlRevokeUserTokens, userID)
+}
@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
-	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
 	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
 )
 
@@ -18,119 +18,162 @@ import (
  * @apiGroup User
  *
  * @apiDescription User register. This also return authentication token for the first time.
+ * When email is sent, the verification link is sent to that email. If the server requires verified email,
+ * the token is not returned and user must verify the email before login.
  *
  * @apiParam (Request body) {String} name Name of this user
  * @apiParam (Request body) {String} username Username of the user. This should be unique.
+ * @apiParam (Request body) {String} [email] Email address to reset the password. This should be unique, and it is required when the server requires verified email.
  * @apiParam (Request body) {String} password User password
+ * @apiParam (Request body) {String} [nonce] OpenID Connect nonce, it will be copied into the ID token
  */
 func (handler *HandlerConfig) RegisterUserHandler(ctx context.Context, req http.Request) http.Response {
 	form := &struct {
-		Name     string `json:"name" form:"name"`
-		Username string `json:"username" form:"username"`
-		Password string `json:"password" form:"password"`
+		Name     string `json:"name" form:"name" validate:"required"`
+		Username string `json:"username" form:"username" validate:"required,max=160"`
+		Email    string `json:"email" form:"email"`
+		Password string `json:"password" form:"password" validate:"required"`
+		Nonce    string `json:"nonce" form:"nonce"`
 	}{}
 
-	if err := req.Bind(form); err != nil {
-		return http.NewJsonResponse(500, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
-			},
-		})
-	}
+	// every field which is not valid is sent in one response, so the form can show all of them at once
+	var details []http.FieldError
+	if err := req.ValidatedBind(form); err != nil {
+		apiError, ok := err.(*http.APIError)
+		if !ok || apiError.Code != http.ErrValidation.Code {
+			return http.NewErrorResponse(err)
+		}
 
-	if strings.TrimSpace(form.Name) == "" {
-		return http.NewJsonResponse(400, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "name cannot be empty",
-			},
-		})
+		details = append(details, apiError.Details...)
 	}
 
-	if strings.TrimSpace(form.Username) == "" {
-		return http.NewJsonResponse(400, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "username cannot be empty",
-			},
+	email, err := normalizeEmail(form.Email)
+	switch {
+	case err != nil:
+		details = append(details, http.FieldError{
+			Field:   "email",
+			Code:    "email",
+			Message: err.Error(),
 		})
+	case email == "" && handler.RequireVerifiedEmail:
+		// depends on server config, so it cannot be written in the tag
+		details = append(details, requiredField("email"))
 	}
 
-	if strings.TrimSpace(form.Password) == "" {
-		return http.NewJsonResponse(400, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "password cannot be empty",
-			},
-		})
+	// empty password is already reported as required
+	if strings.TrimSpace(form.Password) != "" {
+		passwordDetails, err := passwordPolicyDetails(handler.PasswordPolicy, form.Password, form.Username)
+		if err != nil {
+			return http.ErrInternal.WithMessage(fmt.Sprintf("fail checking password: %s", err.Error())).Response()
+		}
+
+		details = append(details, passwordDetails...)
 	}
 
-	// check if user already exist
-	user := &model.User{}
-	handler.DB.Raw(user, "SELECT * FROM users WHERE username = ? LIMIT 1", form.Username)
-	if user != nil && user.ID != 0 {
-		return http.NewJsonResponse(400, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "user with this username already registered",
-			},
-		})
+	if len(details) > 0 {
+		return http.ErrValidation.WithDetails(details...).Response()
 	}
 
-	passwordHash, err := HashPassword(form.Password)
+	// hash the password first, so the transaction is not kept open while hashing
+	passwordHash, err := handler.PasswordHasher.Hash(form.Password)
 	if err != nil {
-		return http.NewJsonResponse(422, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": fmt.Sprintf("fail when hashing password: %s", err.Error()),
-			},
-		})
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail when hashing password: %s", err.Error())).Response()
 	}
 
 	var sqlInsertUser = `
-		INSERT INTO users (name, username, password) VALUES (?, ?, ?) ON CONFLICT(username) DO UPDATE SET updated_at = now() RETURNING *;
+		INSERT INTO users (name, username, email, password) VALUES (?, ?, NULLIF(?, ''), ?) ON CONFLICT DO NOTHING RETURNING *;
 	`
 
-	// insert to db user in database
-	err = handler.DB.Raw(user, sqlInsertUser, form.Name, form.Username, passwordHash)
+	// check if user already exist then insert it in one transaction, the error is APIError when the username or email is taken
+	user := &model.User{}
+	err = handler.DB.RunInTx(ctx, func(tx db.Query) error {
+		existing := &model.User{}
+		if err := tx.Raw(ctx, existing, "SELECT * FROM users WHERE username = ? LIMIT 1", form.Username); err != nil {
+			return err
+		}
+
+		if existing.ID != 0 {
+			return errUsernameTaken
+		}
+
+		if email != "" {
+			if err := tx.Raw(ctx, existing, "SELECT * FROM users WHERE email = ? LIMIT 1", email); err != nil {
+				return err
+			}
+
+			if existing.ID != 0 {
+				return errEmailTaken
+			}
+		}
+
+		if err := tx.Raw(ctx, user, sqlInsertUser, form.Name, form.Username, email, passwordHash); err != nil {
+			return fmt.Errorf("fail inserting user into db: %s", err.Error())
+		}
+
+		// other request registers the same username or email after the check
+		if user.ID == 0 {
+			return http.ErrConflict.WithMessage("user with this username or email already registered")
+		}
+
+		return nil
+	})
 	if err != nil {
-		return http.NewJsonResponse(422, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": fmt.Sprintf("fail inserting user into db: %s", err.Error()),
-			},
-		})
+		return http.NewErrorResponse(err)
 	}
 
-	// Check password hash is different or not with body json data, if different, it may because attacking.
-	// If still the same, it may because race condition in request (2 or more request at one time)
-	if !CheckPasswordHash(form.Password, user.Password) {
-		return http.NewJsonResponse(401, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "wrong password",
+	if user.Email != "" && user.EmailVerifiedAt == nil {
+		if err := handler.sendEmailVerification(ctx, user); err != nil {
+			return http.ErrInternal.WithMessage(fmt.Sprintf("fail sending verification email: %s", err.Error())).Response()
+		}
+	}
+
+	// user must open the link in verification email before getting the token
+	if handler.mustVerifyEmail(user) {
+		return http.NewJsonResponse(200, map[string]interface{}{
+			"message": "please open the link sent to your email to verify it before login",
+			"user": map[string]interface{}{
+				"id":             user.ID,
+				"name":           user.Name,
+				"username":       user.Username,
+				"email":          user.Email,
+				"email_verified": false,
+				"registered_at":  user.CreatedAt.Unix(),
 			},
 		})
 	}
 
-	// if found, then check hashing password
-	tokenPayload := &auth.Payload{
-		ID:        fmt.Sprintf("%d", user.ID),
-		Username:  user.Username,
-		IssuedAt:  time.Now().Unix(),
-		NotBefore: time.Now().Unix(),
-		ExpiredAt: time.Now().Add(5 * time.Hour).Unix(),
+	accessToken, err := handler.generateAccessToken(ctx, user, nil)
+	if err != nil {
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail generating access token: %s", err.Error())).Response()
 	}
 
-	accessToken, err := handler.Auth.GenerateToken(tokenPayload, handler.ServerSecretKey)
+	idToken, err := handler.generateIDToken(user, handler.Audience, form.Nonce, time.Now())
 	if err != nil {
-		return http.NewJsonResponse(422, map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": fmt.Sprintf("fail generating access token: %s", err.Error()),
-			},
-		})
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail generating id token: %s", err.Error())).Response()
 	}
 
-	return http.NewJsonResponse(200, map[string]interface{}{
-		"access_token": accessToken,
+	refreshToken, err := handler.generateRefreshToken(ctx, user, "", nil)
+	if err != nil {
+		return http.ErrInternal.WithMessage(fmt.Sprintf("fail generating refresh token: %s", err.Error())).Response()
+	}
+
+	body := map[string]interface{}{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
 		"user": map[string]interface{}{
-			"id":            user.ID,
-			"name":          user.Name,
-			"username":      user.Username,
-			"registered_at": user.CreatedAt.Unix(),
+			"id":             user.ID,
+			"name":           user.Name,
+			"username":       user.Username,
+			"email":          user.Email,
+			"email_verified": user.EmailVerifiedAt != nil,
+			"registered_at":  user.CreatedAt.Unix(),
 		},
-	})
+	}
+
+	// only when the server signs the token using asymmetric key
+	if idToken != "" {
+		body["id_token"] = idToken
+	}
+
+	return http.NewJsonResponse(200, body)
 }
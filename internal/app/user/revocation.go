@@ -0,0 +1,121 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+)
+
+// notRevokedCacheLifetime is how long a "not revoked" answer from database is trusted.
+// Token revoked by another server instance will be rejected here at most after this duration.
+const notRevokedCacheLifetime = 5 * time.Second
+
+// maxNotRevokedCache is the number of "not revoked" answer before we try to remove the stale one.
+const maxNotRevokedCache = 10000
+
+// TokenRevocation keeps the list of revoked access token (by jti) until the token is expired.
+// Postgres table revoked_tokens is the source of truth, and the answer is cached in memory
+// so we don't need to hit database on every request for the same token.
+type TokenRevocation struct {
+	db db.Query
+
+	mutex      sync.RWMutex
+	revoked    map[string]time.Time // jti => token expiration time
+	notRevoked map[string]time.Time // jti => time when this cache entry is stale
+}
+
+// NewTokenRevocation creates revocation store using revoked_tokens table
+func NewTokenRevocation(db db.Query) *TokenRevocation {
+	return &TokenRevocation{
+		db:         db,
+		revoked:    map[string]time.Time{},
+		notRevoked: map[string]time.Time{},
+	}
+}
+
+// Revoke saves the jti into revoked list. expiredAt is the token expiration time,
+// after that time the token is rejected anyway, so we can safely forget it.
+func (revocation *TokenRevocation) Revoke(ctx context.Context, jti string, userID int64, expiredAt time.Time) error {
+	var sqlInsertRevokedToken = `
+		INSERT INTO revoked_tokens (jti, user_id, expired_at) VALUES (?, ?, ?) ON CONFLICT(jti) DO NOTHING;
+	`
+
+	if err := revocation.db.Exec(ctx, sqlInsertRevokedToken, jti, userID, expiredAt); err != nil {
+		return err
+	}
+
+	// clean up the token which is already expired
+	if err := revocation.db.Exec(ctx, "DELETE FROM revoked_tokens WHERE expired_at < now();"); err != nil {
+		return err
+	}
+
+	revocation.mutex.Lock()
+	defer revocation.mutex.Unlock()
+
+	revocation.removeStaleCache(time.Now())
+	revocation.revoked[jti] = expiredAt
+	delete(revocation.notRevoked, jti)
+	return nil
+}
+
+// IsRevoked returns true if the token with this jti is already revoked.
+func (revocation *TokenRevocation) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	now := time.Now()
+
+	revocation.mutex.RLock()
+	_, revoked := revocation.revoked[jti]
+	staleAt, notRevoked := revocation.notRevoked[jti]
+	revocation.mutex.RUnlock()
+
+	if revoked {
+		return true, nil
+	}
+
+	if notRevoked && staleAt.After(now) {
+		return false, nil
+	}
+
+	var result struct {
+		Jti       string
+		ExpiredAt time.Time
+	}
+
+	err := revocation.db.Raw(ctx, &result, "SELECT jti, expired_at FROM revoked_tokens WHERE jti = ? LIMIT 1;", jti)
+	if err != nil {
+		return false, err
+	}
+
+	revocation.mutex.Lock()
+	defer revocation.mutex.Unlock()
+
+	if result.Jti != "" {
+		revocation.revoked[jti] = result.ExpiredAt
+		delete(revocation.notRevoked, jti)
+		return true, nil
+	}
+
+	if len(revocation.notRevoked) >= maxNotRevokedCache {
+		revocation.removeStaleCache(now)
+	}
+
+	revocation.notRevoked[jti] = now.Add(notRevokedCacheLifetime)
+	return false, nil
+}
+
+// removeStaleCache removes expired token and stale "not revoked" answer from memory.
+// Caller must hold the write lock.
+func (revocation *TokenRevocation) removeStaleCache(now time.Time) {
+	for jti, expiredAt := range revocation.revoked {
+		if expiredAt.Before(now) {
+			delete(revocation.revoked, jti)
+		}
+	}
+
+	for jti, staleAt := range revocation.notRevoked {
+		if staleAt.Before(now) {
+			delete(revocation.notRevoked, jti)
+		}
+	}
+}
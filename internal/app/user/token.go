@@ -0,0 +1,220 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+)
+
+const (
+	accessTokenLifetime       = 15 * time.Minute
+	clientAccessTokenLifetime = 1 * time.Hour
+	idTokenLifetime           = 1 * time.Hour
+	refreshTokenLifetime      = 30 * 24 * time.Hour
+)
+
+// oauthGrant is the OAuth client which gets the user token using authorization code grant, and the scope allowed by the user.
+// It is nil for the token used by our own frontend.
+type oauthGrant struct {
+	ClientID string
+	Scope    string
+}
+
+// hasScope returns true when the user allows this scope
+func (grant *oauthGrant) hasScope(scope string) bool {
+	return containsString(strings.Fields(grant.Scope), scope)
+}
+
+// grantOfRefreshToken returns the grant which the refresh token is issued to, so the rotated token keeps the same client and scope
+func grantOfRefreshToken(refreshToken *model.RefreshToken) *oauthGrant {
+	if refreshToken.ClientID == "" {
+		return nil
+	}
+
+	return &oauthGrant{
+		ClientID: refreshToken.ClientID,
+		Scope:    refreshToken.Scope,
+	}
+}
+
+// generateAccessToken creates signed access token for this user.
+// Roles and permissions of the user are put in the token, so the change is only applied in the next token.
+// When grant is not nil, the token is bound to the OAuth client: it is rejected by MiddlewareAuthTokenCheck,
+// and roles and permissions are only put when the user allows scopeRoles.
+func (handler *HandlerConfig) generateAccessToken(ctx context.Context, user *model.User, grant *oauthGrant) (accessToken string, err error) {
+	tokenPayload, err := handler.accessTokenPayload(ctx, user, grant)
+	if err != nil {
+		return
+	}
+
+	accessToken, err = handler.Auth.GenerateToken(tokenPayload, handler.ServerSecretKey)
+	return
+}
+
+// accessTokenPayload returns the payload which is signed by generateAccessToken.
+// Use this when the jti must be saved before the token is issued, so the token can be revoked later.
+func (handler *HandlerConfig) accessTokenPayload(ctx context.Context, user *model.User, grant *oauthGrant) (tokenPayload *auth.Payload, err error) {
+	jti, err := GenerateRandomToken(16)
+	if err != nil {
+		return
+	}
+
+	tokenPayload = &auth.Payload{
+		ID:        fmt.Sprintf("%d", user.ID),
+		Username:  user.Username,
+		Version:   user.TokenVersion,
+		JTI:       jti,
+		IssuedAt:  time.Now().Unix(),
+		NotBefore: time.Now().Unix(),
+		ExpiredAt: time.Now().Add(accessTokenLifetime).Unix(),
+	}
+
+	if grant != nil {
+		tokenPayload.AuthorizedParty = grant.ClientID
+		tokenPayload.Scope = grant.Scope
+	}
+
+	if grant == nil || grant.hasScope(scopeRoles) {
+		tokenPayload.Roles, tokenPayload.Permissions, err = handler.userRolesAndPermissions(ctx, user)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// userRolesAndPermissions returns role names and permission names of the user
+func (handler *HandlerConfig) userRolesAndPermissions(ctx context.Context, user *model.User) (roles []string, permissions []string, err error) {
+	var sqlGetRoles = `
+		SELECT roles.* FROM roles
+		JOIN user_roles ON user_roles.role_id = roles.id
+		WHERE user_roles.user_id = ? ORDER BY roles.name;
+	`
+
+	var userRoles []model.Role
+	if err = handler.DB.Raw(ctx, &userRoles, sqlGetRoles, user.ID); err != nil {
+		return
+	}
+
+	var sqlGetPermissions = `
+		SELECT DISTINCT permissions.* FROM permissions
+		JOIN role_permissions ON role_permissions.permission_id = permissions.id
+		JOIN user_roles ON user_roles.role_id = role_permissions.role_id
+		WHERE user_roles.user_id = ? ORDER BY permissions.name;
+	`
+
+	var userPermissions []model.Permission
+	if err = handler.DB.Raw(ctx, &userPermissions, sqlGetPermissions, user.ID); err != nil {
+		return
+	}
+
+	for _, role := range userRoles {
+		roles = append(roles, role.Name)
+	}
+
+	for _, permission := range userPermissions {
+		permissions = append(permissions, permission.Name)
+	}
+
+	return
+}
+
+// generateClientAccessToken creates signed access token for service client, the subject of this token is the client id.
+// There is no refresh token for service client, it can ask the new token using its secret anytime.
+func (handler *HandlerConfig) generateClientAccessToken(client *model.ServiceClient) (accessToken string, err error) {
+	jti, err := GenerateRandomToken(16)
+	if err != nil {
+		return
+	}
+
+	tokenPayload := &auth.Payload{
+		ID:        client.ClientID,
+		Username:  client.Name,
+		ClientID:  client.ClientID,
+		JTI:       jti,
+		IssuedAt:  time.Now().Unix(),
+		NotBefore: time.Now().Unix(),
+		ExpiredAt: time.Now().Add(clientAccessTokenLifetime).Unix(),
+	}
+
+	accessToken, err = handler.Auth.GenerateToken(tokenPayload, handler.ServerSecretKey)
+	return
+}
+
+// idTokenEnabled returns true when the token is signed using asymmetric key. ID token signed using the HS256 secret key
+// cannot be validated by the client, since the secret key is never published in the JWKS.
+func (handler *HandlerConfig) idTokenEnabled() bool {
+	provider, ok := handler.Auth.(auth.KeySetProvider)
+	return ok && len(provider.KeySet().Keys) > 0
+}
+
+// generateIDToken creates OpenID Connect ID token for this user.
+// audience is the client id which receives this token, and authTime is when the user enters the password.
+// It returns empty token when ID token is not enabled, see idTokenEnabled.
+func (handler *HandlerConfig) generateIDToken(user *model.User, audience, nonce string, authTime time.Time) (idToken string, err error) {
+	if !handler.idTokenEnabled() {
+		return
+	}
+
+	now := time.Now()
+	idTokenPayload := &auth.IDTokenPayload{
+		Issuer:            handler.Issuer,
+		Subject:           fmt.Sprintf("%d", user.ID),
+		Audience:          audience,
+		ExpiredAt:         now.Add(idTokenLifetime).Unix(),
+		IssuedAt:          now.Unix(),
+		AuthTime:          authTime.Unix(),
+		Nonce:             nonce,
+		Name:              user.Name,
+		PreferredUsername: user.Username,
+		UpdatedAt:         user.UpdatedAt.Unix(),
+	}
+
+	idToken, err = handler.Auth.GenerateIDToken(idTokenPayload, handler.ServerSecretKey)
+	return
+}
+
+// generateRefreshToken creates new refresh token for this user and save the hash into database.
+// When familyID is empty, this will start a new family, which is what login and register do.
+// Rotating the refresh token must pass the family id and the grant of the old token, grant is nil for our own frontend.
+func (handler *HandlerConfig) generateRefreshToken(ctx context.Context, user *model.User, familyID string, grant *oauthGrant) (refreshToken string, err error) {
+	return handler.generateRefreshTokenInTx(ctx, handler.DB, user, familyID, grant)
+}
+
+// generateRefreshTokenInTx is generateRefreshToken which saves the token using tx,
+// so it is saved together with other changes like marking the old token as used.
+func (handler *HandlerConfig) generateRefreshTokenInTx(ctx context.Context, tx db.Query, user *model.User, familyID string, grant *oauthGrant) (refreshToken string, err error) {
+	if familyID == "" {
+		familyID, err = GenerateRandomToken(16)
+		if err != nil {
+			return
+		}
+	}
+
+	refreshToken, err = GenerateRandomToken(32)
+	if err != nil {
+		return
+	}
+
+	var clientID, scope string
+	if grant != nil {
+		clientID, scope = grant.ClientID, grant.Scope
+	}
+
+	var sqlInsertRefreshToken = `
+		INSERT INTO refresh_tokens (user_id, family_id, client_id, scope, token_hash, expired_at) VALUES (?, ?, ?, ?, ?, ?);
+	`
+
+	err = tx.Exec(ctx, sqlInsertRefreshToken, user.ID, familyID, clientID, scope, HashToken(refreshToken), time.Now().Add(refreshTokenLifetime))
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
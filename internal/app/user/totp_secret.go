@@ -0,0 +1,72 @@
+package user
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// encryptedTOTPSecretPrefix marks the secret which is encrypted, secret saved before the encryption is plain base32
+const encryptedTOTPSecretPrefix = "enc:"
+
+// totpSecretCipher returns AES-256-GCM keyed from the server secret key.
+// The key is derived with a label, so it is not the same bytes as the key used to sign the token.
+func (handler *HandlerConfig) totpSecretCipher() (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte("totp-secret:" + handler.ServerSecretKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptTOTPSecret encrypts the secret before it is saved in database, so reading users table
+// is not enough to generate the code of authenticator app.
+func (handler *HandlerConfig) encryptTOTPSecret(secret string) (string, error) {
+	aead, err := handler.totpSecretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return encryptedTOTPSecretPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// decryptTOTPSecret returns the base32 secret saved by encryptTOTPSecret.
+// Secret without the prefix is returned as is, since it is saved before the secret is encrypted.
+func (handler *HandlerConfig) decryptTOTPSecret(saved string) (string, error) {
+	if !strings.HasPrefix(saved, encryptedTOTPSecretPrefix) {
+		return saved, nil
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(saved, encryptedTOTPSecretPrefix))
+	if err != nil {
+		return "", fmt.Errorf("totp secret is not valid: %s", err.Error())
+	}
+
+	aead, err := handler.totpSecretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("totp secret is not valid: too short")
+	}
+
+	secret, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("fail decrypting totp secret, is the server secret key changed? %s", err.Error())
+	}
+
+	return string(secret), nil
+}
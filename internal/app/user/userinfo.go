@@ -0,0 +1,30 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+/**
+ * @api {get} /userinfo User Info
+ * @apiVersion 1.0.0
+ * @apiName User Info
+ * @apiGroup OpenID Connect
+ *
+ * @apiDescription OpenID Connect UserInfo endpoint. Returns the same user as profile, using standard claims.
+ * This endpoint is not prefixed with /api/v1.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ */
+func (handler *HandlerConfig) UserInfoHandler(ctx context.Context, req http.Request) http.Response {
+	user := req.User()
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"sub":                fmt.Sprintf("%d", user.ID),
+		"name":               user.Name,
+		"preferred_username": user.Username,
+		"updated_at":         user.UpdatedAt.Unix(),
+	})
+}
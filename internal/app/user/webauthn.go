@@ -0,0 +1,530 @@
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/webauthn"
+)
+
+// ceremony of webauthn_sessions
+const (
+	webAuthnRegistration = "registration"
+	webAuthnLogin        = "login"
+)
+
+/**
+ * @api {post} /user/webauthn/register/options WebAuthn Register Options
+ * @apiVersion 1.0.0
+ * @apiName WebAuthn Register Options
+ * @apiGroup WebAuthn
+ *
+ * @apiDescription Start registering passkey or security key. The response is PublicKeyCredentialCreationOptions,
+ * where challenge, user.id and excludeCredentials[].id are base64url encoded. Decode them into ArrayBuffer,
+ * call navigator.credentials.create() and send the result to /user/webauthn/register within 5 minutes.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ */
+func (handler *HandlerConfig) WebAuthnRegisterOptionsHandler(ctx context.Context, req http.Request) http.Response {
+	user := req.User()
+
+	credentials := make([]model.WebAuthnCredential, 0)
+	err := handler.DB.Raw(ctx, &credentials, "SELECT * FROM webauthn_credentials WHERE user_id = ? ORDER BY id;", user.ID)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail getting credentials: %s", err.Error()),
+			},
+		})
+	}
+
+	exclude := make([][]byte, 0, len(credentials))
+	for _, credential := range credentials {
+		credentialID, _ := base64.RawURLEncoding.DecodeString(credential.CredentialID)
+		exclude = append(exclude, credentialID)
+	}
+
+	challenge, errResp := handler.createWebAuthnSession(ctx, webAuthnRegistration, &user.ID, "")
+	if errResp != nil {
+		return errResp
+	}
+
+	displayName := user.Name
+	if displayName == "" {
+		displayName = user.Username
+	}
+
+	userHandle := []byte(strconv.FormatInt(user.ID, 10))
+	return http.NewJsonResponse(200, handler.WebAuthn.CreationOptions(challenge, userHandle, user.Username, displayName, exclude))
+}
+
+/**
+ * @api {post} /user/webauthn/register WebAuthn Register
+ * @apiVersion 1.0.0
+ * @apiName WebAuthn Register
+ * @apiGroup WebAuthn
+ *
+ * @apiDescription Save the credential created by navigator.credentials.create(). Binary values are base64url encoded.
+ * After this, user can login using /user/webauthn/login without password.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiParam (Request body) {String} client_data_json response.clientDataJSON
+ * @apiParam (Request body) {String} attestation_object response.attestationObject
+ * @apiParam (Request body) {String} [name] Name to recognize this credential, for example "My laptop"
+ */
+func (handler *HandlerConfig) WebAuthnRegisterHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		ClientDataJSON    string `json:"client_data_json" form:"client_data_json"`
+		AttestationObject string `json:"attestation_object" form:"attestation_object"`
+		Name              string `json:"name" form:"name"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	clientDataJSON, err1 := decodeBase64URL(form.ClientDataJSON)
+	attestationObject, err2 := decodeBase64URL(form.AttestationObject)
+	if err1 != nil || err2 != nil || len(clientDataJSON) == 0 || len(attestationObject) == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "client_data_json and attestation_object must be base64url encoded and cannot be empty",
+			},
+		})
+	}
+
+	user := req.User()
+	challenge, session, errResp := handler.useWebAuthnSession(ctx, webAuthnRegistration, clientDataJSON)
+	if errResp != nil {
+		return errResp
+	}
+
+	if session.UserID == nil || *session.UserID != user.ID {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "challenge is not created for this user",
+			},
+		})
+	}
+
+	credential, err := handler.WebAuthn.VerifyRegistration(clientDataJSON, attestationObject, challenge)
+	if err != nil {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("credential is not valid: %s", err.Error()),
+			},
+		})
+	}
+
+	var sqlInsertCredential = `
+		INSERT INTO webauthn_credentials (user_id, credential_id, public_key, sign_count, name) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (credential_id) DO NOTHING RETURNING *;
+	`
+
+	saved := &model.WebAuthnCredential{}
+	err = handler.DB.Raw(ctx, saved, sqlInsertCredential, user.ID, base64.RawURLEncoding.EncodeToString(credential.ID),
+		credential.PublicKey, int64(credential.SignCount), strings.TrimSpace(form.Name))
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail saving credential: %s", err.Error()),
+			},
+		})
+	}
+
+	if saved.ID == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "credential is already registered",
+			},
+		})
+	}
+
+	return http.NewJsonResponse(201, map[string]interface{}{
+		"credential": webAuthnCredentialResponse(saved),
+	})
+}
+
+/**
+ * @api {post} /user/webauthn/login/options WebAuthn Login Options
+ * @apiVersion 1.0.0
+ * @apiName WebAuthn Login Options
+ * @apiGroup WebAuthn
+ *
+ * @apiDescription Start login using passkey or security key. The response is PublicKeyCredentialRequestOptions,
+ * where challenge and allowCredentials[].id are base64url encoded. Call navigator.credentials.get()
+ * and send the result to /user/webauthn/login within 5 minutes.
+ * When username is empty, allowCredentials is empty and browser lets user choose one of the passkeys.
+ * Unknown username and user without credential still get the options, but no authenticator can answer it.
+ *
+ * @apiParam (Request body) {String} [username] Username, to login using security key which is not discoverable
+ * @apiParam (Request body) {String} [nonce] OpenID Connect nonce, it will be copied into the ID token
+ */
+func (handler *HandlerConfig) WebAuthnLoginOptionsHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Username string `json:"username" form:"username"`
+		Nonce    string `json:"nonce" form:"nonce"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	var userID *int64
+	allow := make([][]byte, 0)
+	if strings.TrimSpace(form.Username) != "" {
+		user := &model.User{}
+		handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE username = ? LIMIT 1", form.Username)
+
+		credentials := make([]model.WebAuthnCredential, 0)
+		if user != nil && user.ID != 0 {
+			err := handler.DB.Raw(ctx, &credentials, "SELECT * FROM webauthn_credentials WHERE user_id = ? ORDER BY id;", user.ID)
+			if err != nil {
+				return http.NewJsonResponse(422, map[string]interface{}{
+					"error": map[string]interface{}{
+						"message": fmt.Sprintf("fail getting credentials: %s", err.Error()),
+					},
+				})
+			}
+
+			userID = &user.ID
+		}
+
+		for _, credential := range credentials {
+			credentialID, _ := base64.RawURLEncoding.DecodeString(credential.CredentialID)
+			allow = append(allow, credentialID)
+		}
+
+		// unknown username and user without credential get the same response as registered one,
+		// so the api doesn't tell which usernames are registered. Browser finds no authenticator for this id.
+		if len(allow) == 0 {
+			allow = append(allow, handler.fakeWebAuthnCredentialID(form.Username))
+		}
+	}
+
+	challenge, errResp := handler.createWebAuthnSession(ctx, webAuthnLogin, userID, form.Nonce)
+	if errResp != nil {
+		return errResp
+	}
+
+	return http.NewJsonResponse(200, handler.WebAuthn.RequestOptions(challenge, allow))
+}
+
+/**
+ * @api {post} /user/webauthn/login WebAuthn Login
+ * @apiVersion 1.0.0
+ * @apiName WebAuthn Login
+ * @apiGroup WebAuthn
+ *
+ * @apiDescription Login using the assertion from navigator.credentials.get(). Binary values are base64url encoded.
+ * The response is the same as login. User verification (PIN or biometric) is required by the options,
+ * so two-factor authentication code is not asked.
+ *
+ * @apiParam (Request body) {String} credential_id rawId of the credential
+ * @apiParam (Request body) {String} client_data_json response.clientDataJSON
+ * @apiParam (Request body) {String} authenticator_data response.authenticatorData
+ * @apiParam (Request body) {String} signature response.signature
+ * @apiParam (Request body) {String} [user_handle] response.userHandle
+ */
+func (handler *HandlerConfig) WebAuthnLoginHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		CredentialID      string `json:"credential_id" form:"credential_id"`
+		ClientDataJSON    string `json:"client_data_json" form:"client_data_json"`
+		AuthenticatorData string `json:"authenticator_data" form:"authenticator_data"`
+		Signature         string `json:"signature" form:"signature"`
+		UserHandle        string `json:"user_handle" form:"user_handle"`
+	}{}
+
+	if err := req.Bind(form); err != nil {
+		return http.NewJsonResponse(500, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail when binding the payload: %s", err.Error()),
+			},
+		})
+	}
+
+	credentialID, err1 := decodeBase64URL(form.CredentialID)
+	clientDataJSON, err2 := decodeBase64URL(form.ClientDataJSON)
+	authenticatorData, err3 := decodeBase64URL(form.AuthenticatorData)
+	signature, err4 := decodeBase64URL(form.Signature)
+	userHandle, err5 := decodeBase64URL(form.UserHandle)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil ||
+		len(credentialID) == 0 || len(clientDataJSON) == 0 || len(authenticatorData) == 0 || len(signature) == 0 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "credential_id, client_data_json, authenticator_data and signature must be base64url encoded and cannot be empty",
+			},
+		})
+	}
+
+	challenge, session, errResp := handler.useWebAuthnSession(ctx, webAuthnLogin, clientDataJSON)
+	if errResp != nil {
+		return errResp
+	}
+
+	credential := &model.WebAuthnCredential{}
+	handler.DB.Raw(ctx, credential, "SELECT * FROM webauthn_credentials WHERE credential_id = ? LIMIT 1",
+		base64.RawURLEncoding.EncodeToString(credentialID))
+	if credential == nil || credential.ID == 0 {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "credential is not registered",
+			},
+		})
+	}
+
+	// the credential must belong to the user in login options, and to the user handle saved in the authenticator
+	if (session.UserID != nil && *session.UserID != credential.UserID) ||
+		(len(userHandle) > 0 && string(userHandle) != strconv.FormatInt(credential.UserID, 10)) {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "credential does not belong to this user",
+			},
+		})
+	}
+
+	storedCredential := &webauthn.Credential{
+		ID:        credentialID,
+		PublicKey: credential.PublicKey,
+		SignCount: uint32(credential.SignCount),
+	}
+
+	signCount, err := handler.WebAuthn.VerifyAssertion(storedCredential, clientDataJSON, authenticatorData, signature, challenge)
+	if err != nil {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("assertion is not valid: %s", err.Error()),
+			},
+		})
+	}
+
+	// the WHERE condition rejects the other login which uses the same counter at the same time
+	var sqlUseCredential = `
+		UPDATE webauthn_credentials SET sign_count = ?, last_used_at = now() WHERE id = ? AND sign_count = ? RETURNING *;
+	`
+
+	usedCredential := &model.WebAuthnCredential{}
+	err = handler.DB.Raw(ctx, usedCredential, sqlUseCredential, int64(signCount), credential.ID, credential.SignCount)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail updating credential: %s", err.Error()),
+			},
+		})
+	}
+
+	if usedCredential.ID == 0 {
+		return http.NewJsonResponse(401, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "credential is used by other login at the same time",
+			},
+		})
+	}
+
+	user := &model.User{}
+	handler.DB.Raw(ctx, user, "SELECT * FROM users WHERE id = ? LIMIT 1", credential.UserID)
+	if user == nil || user.ID == 0 {
+		return http.NewJsonResponse(404, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "user not found",
+			},
+		})
+	}
+
+	if user.DisabledAt != nil {
+		return http.NewJsonResponse(403, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "user is disabled",
+			},
+		})
+	}
+
+	if handler.mustVerifyEmail(user) {
+		return errEmailNotVerified.Response()
+	}
+
+	return handler.loginResponse(ctx, user, session.Nonce)
+}
+
+/**
+ * @api {get} /user/webauthn/credentials WebAuthn Credentials
+ * @apiVersion 1.0.0
+ * @apiName WebAuthn Credentials
+ * @apiGroup WebAuthn
+ *
+ * @apiDescription List passkeys and security keys registered by this user.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ */
+func (handler *HandlerConfig) WebAuthnCredentialsHandler(ctx context.Context, req http.Request) http.Response {
+	credentials := make([]model.WebAuthnCredential, 0)
+	err := handler.DB.Raw(ctx, &credentials, "SELECT * FROM webauthn_credentials WHERE user_id = ? ORDER BY id;", req.User().ID)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail getting credentials: %s", err.Error()),
+			},
+		})
+	}
+
+	data := make([]map[string]interface{}, 0, len(credentials))
+	for i := range credentials {
+		data = append(data, webAuthnCredentialResponse(&credentials[i]))
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"credentials": data,
+	})
+}
+
+/**
+ * @api {delete} /user/webauthn/credentials/:id WebAuthn Delete Credential
+ * @apiVersion 1.0.0
+ * @apiName WebAuthn Delete Credential
+ * @apiGroup WebAuthn
+ *
+ * @apiDescription Remove the credential, so it cannot be used to login anymore.
+ *
+ * @apiUse MiddlewareAuthTokenCheck
+ * @apiParam (Url parameter) {Number} id Credential id from the list
+ */
+func (handler *HandlerConfig) WebAuthnDeleteCredentialHandler(ctx context.Context, req http.Request) http.Response {
+	id, err := strconv.ParseInt(req.GetParam("id"), 10, 64)
+	if err != nil || id < 1 {
+		return http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "id must be a positive number",
+			},
+		})
+	}
+
+	deleted := &model.WebAuthnCredential{}
+	err = handler.DB.Raw(ctx, deleted, "DELETE FROM webauthn_credentials WHERE id = ? AND user_id = ? RETURNING *;", id, req.User().ID)
+	if err != nil {
+		return http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail deleting credential: %s", err.Error()),
+			},
+		})
+	}
+
+	if deleted.ID == 0 {
+		return http.NewJsonResponse(404, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "credential not found",
+			},
+		})
+	}
+
+	return http.NewJsonResponse(200, map[string]interface{}{
+		"message": "credential deleted",
+	})
+}
+
+// createWebAuthnSession saves new challenge, only the hash is saved like other tokens.
+func (handler *HandlerConfig) createWebAuthnSession(ctx context.Context, ceremony string, userID *int64, nonce string) (challenge string, errResp http.Response) {
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		return "", http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail generating challenge: %s", err.Error()),
+			},
+		})
+	}
+
+	var sqlInsertSession = `
+		INSERT INTO webauthn_sessions (challenge_hash, ceremony, user_id, nonce, expired_at) VALUES (?, ?, ?, ?, ?);
+	`
+
+	err = handler.DB.Exec(ctx, sqlInsertSession, HashToken(challenge), ceremony, userID, nonce, time.Now().Add(webauthn.Timeout))
+	if err != nil {
+		return "", http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail saving challenge: %s", err.Error()),
+			},
+		})
+	}
+
+	return challenge, nil
+}
+
+// useWebAuthnSession finds the session using the challenge in client data and marks it as used,
+// so one challenge can only be used once even when the verification fails.
+func (handler *HandlerConfig) useWebAuthnSession(ctx context.Context, ceremony string, clientDataJSON []byte) (challenge string, session *model.WebAuthnSession, errResp http.Response) {
+	clientData, err := webauthn.ParseClientData(clientDataJSON)
+	if err != nil || clientData.Challenge == "" {
+		return "", nil, http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "client_data_json is not valid",
+			},
+		})
+	}
+
+	var sqlUseSession = `
+		UPDATE webauthn_sessions SET used_at = now()
+		WHERE challenge_hash = ? AND ceremony = ? AND used_at IS NULL AND expired_at > now() RETURNING *;
+	`
+
+	session = &model.WebAuthnSession{}
+	err = handler.DB.Raw(ctx, session, sqlUseSession, HashToken(clientData.Challenge), ceremony)
+	if err != nil {
+		return "", nil, http.NewJsonResponse(422, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": fmt.Sprintf("fail updating challenge: %s", err.Error()),
+			},
+		})
+	}
+
+	if session.ID == 0 {
+		return "", nil, http.NewJsonResponse(400, map[string]interface{}{
+			"error": map[string]interface{}{
+				"message": "challenge is not valid, already used or expired",
+			},
+		})
+	}
+
+	return clientData.Challenge, session, nil
+}
+
+func webAuthnCredentialResponse(credential *model.WebAuthnCredential) map[string]interface{} {
+	var lastUsedAt interface{}
+	if credential.LastUsedAt != nil {
+		lastUsedAt = credential.LastUsedAt.Unix()
+	}
+
+	return map[string]interface{}{
+		"id":            credential.ID,
+		"credential_id": credential.CredentialID,
+		"name":          credential.Name,
+		"last_used_at":  lastUsedAt,
+		"created_at":    credential.CreatedAt.Unix(),
+	}
+}
+
+// fakeWebAuthnCredentialID returns credential id which is always the same for the username, but never registered.
+func (handler *HandlerConfig) fakeWebAuthnCredentialID(username string) []byte {
+	mac := hmac.New(sha256.New, []byte(handler.ServerSecretKey))
+	mac.Write([]byte("webauthn credential id:" + username))
+	return mac.Sum(nil)
+}
+
+// decodeBase64URL decodes base64url with or without padding, since browsers and libraries differ.
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(value), "="))
+}
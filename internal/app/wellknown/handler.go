@@ -0,0 +1,17 @@
+package wellknown
+
+import (
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+)
+
+type HandlerConfig struct {
+	Issuer string
+	Auth   auth.Auth
+}
+
+func NewWellKnownHandler(issuer string, auth auth.Auth) *HandlerConfig {
+	return &HandlerConfig{
+		Issuer: issuer,
+		Auth:   auth,
+	}
+}
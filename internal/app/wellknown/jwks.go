@@ -0,0 +1,32 @@
+package wellknown
+
+import (
+	"context"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+/**
+ * @api {get} /.well-known/jwks.json JSON Web Key Set
+ * @apiVersion 1.0.0
+ * @apiName JWKS
+ * @apiGroup Well Known
+ *
+ * @apiDescription Public keys to validate the access token, in JWK Set format (RFC 7517).
+ * Use the `kid` header of the token to choose the key. When the server signs the token using HS256 secret key,
+ * the key set is empty since the secret key must not be published.
+ */
+func (handler *HandlerConfig) JWKSHandler(ctx context.Context, req http.Request) http.Response {
+	keySet := auth.JSONWebKeySet{
+		Keys: []auth.JSONWebKey{},
+	}
+
+	if provider, ok := handler.Auth.(auth.KeySetProvider); ok {
+		keySet = provider.KeySet()
+	}
+
+	resp := http.NewJsonResponse(200, keySet)
+	resp.Header().Set("Cache-Control", "public, max-age=300")
+	return resp
+}
@@ -0,0 +1,60 @@
+package wellknown
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+/**
+ * @api {get} /.well-known/openid-configuration OpenID Connect Discovery
+ * @apiVersion 1.0.0
+ * @apiName OpenID Configuration
+ * @apiGroup Well Known
+ *
+ * @apiDescription OpenID Provider metadata, see https://openid.net/specs/openid-connect-discovery-1_0.html
+ * It is only available when the token is signed using asymmetric key, otherwise the response is 404.
+ */
+func (handler *HandlerConfig) OpenIDConfigurationHandler(ctx context.Context, req http.Request) http.Response {
+	alg := handler.signingAlgorithm()
+	if alg == "" {
+		return http.ErrNotFound.WithMessage("OpenID Connect is not enabled, the token must be signed using asymmetric key").Response()
+	}
+
+	issuer := strings.TrimRight(handler.Issuer, "/")
+
+	resp := http.NewJsonResponse(200, map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth/authorize",
+		"token_endpoint":                        issuer + "/oauth/token",
+		"jwks_uri":                              issuer + "/.well-known/jwks.json",
+		"userinfo_endpoint":                     issuer + "/userinfo",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code", "client_credentials"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"token_endpoint_auth_methods_supported": []string{"none", "client_secret_basic", "client_secret_post"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{alg},
+		"scopes_supported":                      []string{"openid", "profile", "roles"},
+		"claims_supported": []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "name", "preferred_username", "updated_at",
+		},
+	})
+	resp.Header().Set("Cache-Control", "public, max-age=300")
+	return resp
+}
+
+// signingAlgorithm returns alg used to sign the token. It is empty when the token is signed using HS256 secret key,
+// since nobody else can validate the ID token without the secret key, so it is not issued at all.
+func (handler *HandlerConfig) signingAlgorithm() string {
+	if provider, ok := handler.Auth.(auth.KeySetProvider); ok {
+		keys := provider.KeySet().Keys
+		if len(keys) > 0 {
+			return keys[0].Alg
+		}
+	}
+
+	return ""
+}
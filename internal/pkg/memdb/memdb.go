@@ -0,0 +1,514 @@
+// Package memdb implements db.Query in memory, so the handlers can be tested without Postgres.
+//
+// It doesn't parse SQL: every statement which is supported is written here as the handler writes it,
+// only the whitespace and the trailing semicolon are ignored. Other statement returns error,
+// so a handler which needs a new statement fails loudly in the test instead of reading nothing.
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+)
+
+type revokedToken struct {
+	Jti       string
+	UserID    int64
+	ExpiredAt time.Time
+}
+
+// tables is the data of all supported tables, it is copied when the transaction begins
+type tables struct {
+	lastID             int64
+	users              []model.User
+	userRoles          map[int64][]string  // role names of the user id
+	rolePermissions    map[string][]string // permission names of the role name
+	refreshTokens      []model.RefreshToken
+	emailVerifications []model.EmailVerification
+	revokedTokens      []revokedToken
+}
+
+func (t *tables) nextID() int64 {
+	t.lastID++
+	return t.lastID
+}
+
+func (t *tables) clone() *tables {
+	c := *t
+	c.users = append([]model.User(nil), t.users...)
+	c.refreshTokens = append([]model.RefreshToken(nil), t.refreshTokens...)
+	c.emailVerifications = append([]model.EmailVerification(nil), t.emailVerifications...)
+	c.revokedTokens = append([]revokedToken(nil), t.revokedTokens...)
+
+	c.userRoles = make(map[int64][]string, len(t.userRoles))
+	for k, v := range t.userRoles {
+		c.userRoles[k] = append([]string(nil), v...)
+	}
+
+	c.rolePermissions = make(map[string][]string, len(t.rolePermissions))
+	for k, v := range t.rolePermissions {
+		c.rolePermissions[k] = append([]string(nil), v...)
+	}
+
+	return &c
+}
+
+func (t *tables) user(match func(user *model.User) bool) *model.User {
+	for i := range t.users {
+		if match(&t.users[i]) {
+			return &t.users[i]
+		}
+	}
+
+	return nil
+}
+
+// DB implements db.Query using the tables in memory. All queries are serialized,
+// and a transaction keeps the lock until it is finished, so it behaves like serializable isolation.
+type DB struct {
+	mutex sync.Mutex
+	data  *tables
+}
+
+// New creates empty database
+func New() *DB {
+	return &DB{
+		data: &tables{
+			userRoles:       map[int64][]string{},
+			rolePermissions: map[string][]string{},
+		},
+	}
+}
+
+// Raw runs the select statement, or statement with RETURNING, and copies the rows into dst like go-pg does:
+// dst is pointer to struct or pointer to slice of struct, and it is not changed when no row is found.
+func (q *DB) Raw(ctx context.Context, dst interface{}, sql string, args ...interface{}) (err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.data.raw(ctx, dst, sql, args)
+}
+
+// Exec runs the statement without returning values
+func (q *DB) Exec(ctx context.Context, sql string, args ...interface{}) (err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.data.exec(ctx, sql, args)
+}
+
+// RunInTx runs fn on the copy of the tables, the copy replaces the tables only when fn returns nil
+func (q *DB) RunInTx(ctx context.Context, fn func(tx db.Query) error) (err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	tx := &txMemory{data: q.data.clone()}
+	if err = fn(tx); err != nil {
+		return
+	}
+
+	q.data = tx.data
+	return nil
+}
+
+// Migrate does nothing, the tables are ready when DB is created
+func (q *DB) Migrate() error {
+	return nil
+}
+
+// AddUser inserts the user as is, only ID and the timestamps are set. It returns the inserted user.
+func (q *DB) AddUser(user model.User) model.User {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := time.Now()
+	user.ID = q.data.nextID()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	q.data.users = append(q.data.users, user)
+	return user
+}
+
+// User returns the user with this id, ok is false when it is not found
+func (q *DB) User(id int64) (user model.User, ok bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	found := q.data.user(func(user *model.User) bool { return user.ID == id })
+	if found == nil {
+		return
+	}
+
+	return *found, true
+}
+
+// UpdateUser calls fn with the user which has this id, so the test can change the columns directly.
+// It returns false when the user is not found.
+func (q *DB) UpdateUser(id int64, fn func(user *model.User)) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	found := q.data.user(func(user *model.User) bool { return user.ID == id })
+	if found == nil {
+		return false
+	}
+
+	fn(found)
+	found.UpdatedAt = time.Now()
+	return true
+}
+
+// GrantRole gives the role to the user, the permissions are added into that role
+func (q *DB) GrantRole(userID int64, role string, permissions ...string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.data.userRoles[userID] = appendUnique(q.data.userRoles[userID], role)
+	for _, permission := range permissions {
+		q.data.rolePermissions[role] = appendUnique(q.data.rolePermissions[role], permission)
+	}
+}
+
+// txMemory implements db.Query inside RunInTx, the lock is already held by RunInTx
+type txMemory struct {
+	data *tables
+}
+
+func (q *txMemory) Raw(ctx context.Context, dst interface{}, sql string, args ...interface{}) (err error) {
+	return q.data.raw(ctx, dst, sql, args)
+}
+
+func (q *txMemory) Exec(ctx context.Context, sql string, args ...interface{}) (err error) {
+	return q.data.exec(ctx, sql, args)
+}
+
+// RunInTx inside transaction runs fn in the same transaction, like go-pg implementation
+func (q *txMemory) RunInTx(ctx context.Context, fn func(tx db.Query) error) (err error) {
+	return fn(q)
+}
+
+func (q *txMemory) Migrate() error {
+	return fmt.Errorf("migration cannot run inside transaction")
+}
+
+func (t *tables) raw(ctx context.Context, dst interface{}, sql string, args []interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	statement, ok := queries[normalizeSQL(sql)]
+	if !ok {
+		return fmt.Errorf("memdb: query is not supported: %s", normalizeSQL(sql))
+	}
+
+	rows, err := statement(t, args)
+	if err != nil {
+		return err
+	}
+
+	return scan(dst, rows)
+}
+
+func (t *tables) exec(ctx context.Context, sql string, args []interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	statement, ok := execs[normalizeSQL(sql)]
+	if !ok {
+		return fmt.Errorf("memdb: statement is not supported: %s", normalizeSQL(sql))
+	}
+
+	return statement(t, args)
+}
+
+// queries are statements which return rows, the key is the normalized sql
+var queries = map[string]func(t *tables, args []interface{}) (rows []interface{}, err error){
+	"SELECT * FROM users WHERE id = ? LIMIT 1": func(t *tables, args []interface{}) ([]interface{}, error) {
+		id, err := int64Arg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return userRow(t.user(func(user *model.User) bool { return user.ID == id })), nil
+	},
+
+	"SELECT * FROM users WHERE username = ? LIMIT 1": func(t *tables, args []interface{}) ([]interface{}, error) {
+		username := stringArg(args, 0)
+		return userRow(t.user(func(user *model.User) bool { return user.Username == username })), nil
+	},
+
+	"SELECT * FROM users WHERE email = ? LIMIT 1": func(t *tables, args []interface{}) ([]interface{}, error) {
+		email := stringArg(args, 0)
+		return userRow(t.user(func(user *model.User) bool { return email != "" && user.Email == email })), nil
+	},
+
+	// username and email are unique, empty email is NULL
+	"INSERT INTO users (name, username, email, password) VALUES (?, ?, NULLIF(?, ''), ?) ON CONFLICT DO NOTHING RETURNING *": func(t *tables, args []interface{}) ([]interface{}, error) {
+		user := model.User{
+			Name:     stringArg(args, 0),
+			Username: stringArg(args, 1),
+			Email:    stringArg(args, 2),
+			Password: stringArg(args, 3),
+		}
+
+		conflict := t.user(func(existing *model.User) bool {
+			return existing.Username == user.Username || (user.Email != "" && existing.Email == user.Email)
+		})
+		if conflict != nil {
+			return nil, nil
+		}
+
+		now := time.Now()
+		user.ID = t.nextID()
+		user.CreatedAt = now
+		user.UpdatedAt = now
+		t.users = append(t.users, user)
+		return []interface{}{user}, nil
+	},
+
+	"SELECT roles.* FROM roles JOIN user_roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = ? ORDER BY roles.name": func(t *tables, args []interface{}) ([]interface{}, error) {
+		userID, err := int64Arg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		names := append([]string(nil), t.userRoles[userID]...)
+		sort.Strings(names)
+
+		rows := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			rows = append(rows, model.Role{Name: name})
+		}
+
+		return rows, nil
+	},
+
+	"SELECT DISTINCT permissions.* FROM permissions JOIN role_permissions ON role_permissions.permission_id = permissions.id JOIN user_roles ON user_roles.role_id = role_permissions.role_id WHERE user_roles.user_id = ? ORDER BY permissions.name": func(t *tables, args []interface{}) ([]interface{}, error) {
+		userID, err := int64Arg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		var names []string
+		for _, role := range t.userRoles[userID] {
+			for _, permission := range t.rolePermissions[role] {
+				names = appendUnique(names, permission)
+			}
+		}
+		sort.Strings(names)
+
+		rows := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			rows = append(rows, model.Permission{Name: name})
+		}
+
+		return rows, nil
+	},
+
+	"SELECT jti, expired_at FROM revoked_tokens WHERE jti = ? LIMIT 1": func(t *tables, args []interface{}) ([]interface{}, error) {
+		jti := stringArg(args, 0)
+		for _, revoked := range t.revokedTokens {
+			if revoked.Jti == jti {
+				return []interface{}{revoked}, nil
+			}
+		}
+
+		return nil, nil
+	},
+}
+
+// execs are statements which don't return rows, the key is the normalized sql
+var execs = map[string]func(t *tables, args []interface{}) error{
+	"UPDATE users SET password = ? WHERE id = ? AND password = ?": func(t *tables, args []interface{}) error {
+		id, err := int64Arg(args, 1)
+		if err != nil {
+			return err
+		}
+
+		user := t.user(func(user *model.User) bool { return user.ID == id && user.Password == stringArg(args, 2) })
+		if user != nil {
+			user.Password = stringArg(args, 0)
+			user.UpdatedAt = time.Now()
+		}
+
+		return nil
+	},
+
+	"INSERT INTO refresh_tokens (user_id, family_id, client_id, scope, token_hash, expired_at) VALUES (?, ?, ?, ?, ?, ?)": func(t *tables, args []interface{}) error {
+		userID, err := int64Arg(args, 0)
+		if err != nil {
+			return err
+		}
+
+		expiredAt, _ := args[5].(time.Time)
+		t.refreshTokens = append(t.refreshTokens, model.RefreshToken{
+			ID:        t.nextID(),
+			UserID:    userID,
+			FamilyID:  stringArg(args, 1),
+			ClientID:  stringArg(args, 2),
+			Scope:     stringArg(args, 3),
+			TokenHash: stringArg(args, 4),
+			ExpiredAt: expiredAt,
+			CreatedAt: time.Now(),
+		})
+
+		return nil
+	},
+
+	// the first argument is the user id of the invalidated tokens, it is the same as the second one
+	"WITH invalidated AS ( UPDATE email_verifications SET used_at = now() WHERE user_id = ? AND used_at IS NULL ) INSERT INTO email_verifications (user_id, email, token_hash, expired_at) VALUES (?, ?, ?, ?)": func(t *tables, args []interface{}) error {
+		userID, err := int64Arg(args, 1)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range t.emailVerifications {
+			if t.emailVerifications[i].UserID == userID && t.emailVerifications[i].UsedAt == nil {
+				t.emailVerifications[i].UsedAt = &now
+			}
+		}
+
+		expiredAt, _ := args[4].(time.Time)
+		t.emailVerifications = append(t.emailVerifications, model.EmailVerification{
+			ID:        t.nextID(),
+			UserID:    userID,
+			Email:     stringArg(args, 2),
+			TokenHash: stringArg(args, 3),
+			ExpiredAt: expiredAt,
+			CreatedAt: now,
+		})
+
+		return nil
+	},
+
+	"INSERT INTO revoked_tokens (jti, user_id, expired_at) VALUES (?, ?, ?) ON CONFLICT(jti) DO NOTHING": func(t *tables, args []interface{}) error {
+		userID, err := int64Arg(args, 1)
+		if err != nil {
+			return err
+		}
+
+		jti := stringArg(args, 0)
+		for _, revoked := range t.revokedTokens {
+			if revoked.Jti == jti {
+				return nil
+			}
+		}
+
+		expiredAt, _ := args[2].(time.Time)
+		t.revokedTokens = append(t.revokedTokens, revokedToken{Jti: jti, UserID: userID, ExpiredAt: expiredAt})
+		return nil
+	},
+
+	"DELETE FROM revoked_tokens WHERE expired_at < now()": func(t *tables, args []interface{}) error {
+		now := time.Now()
+		kept := t.revokedTokens[:0]
+		for _, revoked := range t.revokedTokens {
+			if !revoked.ExpiredAt.Before(now) {
+				kept = append(kept, revoked)
+			}
+		}
+
+		t.revokedTokens = kept
+		return nil
+	},
+}
+
+// normalizeSQL joins the lines of sql using single space and removes the trailing semicolon
+func normalizeSQL(sql string) string {
+	return strings.TrimSuffix(strings.Join(strings.Fields(sql), " "), ";")
+}
+
+func userRow(user *model.User) []interface{} {
+	if user == nil {
+		return nil
+	}
+
+	return []interface{}{*user}
+}
+
+func stringArg(args []interface{}, i int) string {
+	if i >= len(args) {
+		return ""
+	}
+
+	return fmt.Sprint(args[i])
+}
+
+// int64Arg accepts number or string, since id in the token payload is string
+func int64Arg(args []interface{}, i int) (int64, error) {
+	if i >= len(args) {
+		return 0, fmt.Errorf("memdb: argument %d is missing", i+1)
+	}
+
+	id, err := strconv.ParseInt(fmt.Sprint(args[i]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("memdb: argument %d is not a number: %s", i+1, err.Error())
+	}
+
+	return id, nil
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+
+	return append(list, value)
+}
+
+// scan copies the fields of rows into dst by field name. dst is pointer to struct, which gets the first row,
+// or pointer to slice of struct, which gets all rows.
+func scan(dst interface{}, rows []interface{}) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("memdb: destination must be a non nil pointer, got %T", dst)
+	}
+
+	value = value.Elem()
+	switch value.Kind() {
+	case reflect.Struct:
+		if len(rows) > 0 {
+			copyFields(value, reflect.ValueOf(rows[0]))
+		}
+
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("memdb: destination must be pointer to slice of struct, got %T", dst)
+		}
+
+		for _, row := range rows {
+			item := reflect.New(value.Type().Elem()).Elem()
+			copyFields(item, reflect.ValueOf(row))
+			value.Set(reflect.Append(value, item))
+		}
+
+	default:
+		return fmt.Errorf("memdb: destination must be pointer to struct or slice, got %T", dst)
+	}
+
+	return nil
+}
+
+func copyFields(dst, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		srcField := src.FieldByName(field.Name)
+		if field.PkgPath != "" || !srcField.IsValid() || !srcField.Type().AssignableTo(field.Type) {
+			continue
+		}
+
+		dst.Field(i).Set(srcField)
+	}
+}
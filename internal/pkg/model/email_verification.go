@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// EmailVerification is a data structure that resemble column in table email_verifications.
+// Only the sha256 hash of the token is saved, the plain token is only sent to the email.
+type EmailVerification struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	Email     string     `json:"email"` // the address which the token is sent to, it is only verified when user still uses this address
+	TokenHash string     `json:"token_hash"`
+	UsedAt    *time.Time `json:"used_at"`
+	ExpiredAt time.Time  `json:"expired_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// MFABackupCode is a data structure that resemble column in table mfa_backup_codes.
+// Backup code is used to login when user loses the authenticator app, each code can only be used once.
+type MFABackupCode struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	CodeHash  string     `json:"code_hash"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// MFAChallenge is a data structure that resemble column in table mfa_challenges.
+// It is created when the password is correct but user still needs to enter the code, the plain token is the mfa_pending_token.
+type MFAChallenge struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	TokenHash string     `json:"token_hash"`
+	Nonce     string     `json:"nonce"`    // OpenID Connect nonce from login request
+	Attempts  int        `json:"attempts"` // number of code entered using this token
+	UsedAt    *time.Time `json:"used_at"`
+	ExpiredAt time.Time  `json:"expired_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// OAuthClient is a data structure that resemble column in table oauth_clients.
+// It is the application which is allowed to ask user authorization using /oauth/authorize.
+type OAuthClient struct {
+	ID           int64     `json:"id"`
+	ClientID     string    `json:"client_id"`
+	Name         string    `json:"name"`
+	RedirectURIs string    `json:"redirect_uris" sql:"redirect_uris"` // one redirect uri per line
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// AllowRedirectURI returns true if redirectURI is exactly the same as one of registered redirect uri.
+func (client *OAuthClient) AllowRedirectURI(redirectURI string) bool {
+	for _, registered := range strings.Split(client.RedirectURIs, "\n") {
+		if strings.TrimSpace(registered) != "" && strings.TrimSpace(registered) == redirectURI {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthorizationCode is a data structure that resemble column in table oauth_authorization_codes.
+type AuthorizationCode struct {
+	ID                   int64      `json:"id"`
+	CodeHash             string     `json:"code_hash"`
+	ClientID             string     `json:"client_id"`
+	UserID               int64      `json:"user_id"`
+	RedirectURI          string     `json:"redirect_uri"`
+	RedirectURIExplicit  bool       `json:"redirect_uri_explicit" sql:"redirect_uri_explicit"` // false when the registered redirect uri is used since client doesn't send it
+	Scope                string     `json:"scope"`
+	CodeChallenge        string     `json:"code_challenge"`
+	CodeChallengeMethod  string     `json:"code_challenge_method"`
+	Nonce                string     `json:"nonce"`
+	AuthTime             time.Time  `json:"auth_time"`
+	UsedAt               *time.Time `json:"used_at"`
+	FamilyID             string     `json:"family_id"`                               // family of refresh token issued using this code, set when the code is used
+	AccessTokenJTI       string     `json:"access_token_jti" sql:"access_token_jti"` // jti of access token issued using this code, set when the code is used
+	AccessTokenExpiredAt *time.Time `json:"access_token_expired_at"`
+	ExpiredAt            time.Time  `json:"expired_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// PasswordReset is a data structure that resemble column in table password_resets.
+// Only the sha256 hash of the token is saved, the plain token is only sent to user email.
+type PasswordReset struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	TokenHash string     `json:"token_hash"`
+	UsedAt    *time.Time `json:"used_at"` // not nil when the password already reset using this token
+	ExpiredAt time.Time  `json:"expired_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// RefreshToken is a data structure that resemble column in table refresh_tokens.
+// Only the sha256 hash of the token is saved, the plain token is only known by the client.
+type RefreshToken struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	FamilyID  string     `json:"family_id"` // all tokens rotated from the same login share this id
+	ClientID  string     `json:"client_id"` // OAuth client which gets this token using authorization code grant, empty for our frontend
+	Scope     string     `json:"scope"`     // scope allowed by the user, only when ClientID is set
+	TokenHash string     `json:"token_hash"`
+	UsedAt    *time.Time `json:"used_at"`    // not nil when this token already exchanged with the new one
+	RevokedAt *time.Time `json:"revoked_at"` // not nil when the whole family is revoked
+	ExpiredAt time.Time  `json:"expired_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
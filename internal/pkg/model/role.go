@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Role is a data structure that resemble column in table roles.
+// User gets all permissions of its roles, see table user_roles and role_permissions.
+type Role struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Permission is a data structure that resemble column in table permissions.
+type Permission struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// ServiceClient is a data structure that resemble column in table service_clients.
+// It is a backend service which gets the token using client_credentials grant, without any user.
+type ServiceClient struct {
+	ID         int64      `json:"id"`
+	ClientID   string     `json:"client_id"`
+	Name       string     `json:"name"`
+	SecretHash string     `json:"-"`           // bcrypt hash of client secret
+	DisabledAt *time.Time `json:"disabled_at"` // not nil when this client cannot get or use the token anymore
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
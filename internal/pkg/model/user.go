@@ -4,10 +4,17 @@ import "time"
 
 // User is a data structure that resemble column in database
 type User struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Username  string    `json:"username"`
-	Password  string    `json:"password"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              int64      `json:"id"`
+	Name            string     `json:"name"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`             // lower case email address, empty when user doesn't set it
+	EmailVerifiedAt *time.Time `json:"email_verified_at"` // not nil when user already opens the link in verification email
+	Password        string     `json:"password"`
+	TokenVersion    int64      `json:"token_version"`                             // increased when password is changed, token with older version is rejected
+	TOTPSecret      string     `json:"totp_secret" sql:"totp_secret"`             // base32 secret of authenticator app encrypted with server secret key, set when user starts the enrollment
+	TOTPEnabledAt   *time.Time `json:"totp_enabled_at" sql:"totp_enabled_at"`     // not nil when user confirms the enrollment, login needs the code afterward
+	TOTPLastCounter int64      `json:"totp_last_counter" sql:"totp_last_counter"` // time step of the last accepted code, so the code cannot be used twice
+	DisabledAt      *time.Time `json:"disabled_at"`                               // not nil when this user is disabled by admin and cannot login
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
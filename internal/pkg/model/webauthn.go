@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// WebAuthnCredential is a data structure that resemble column in table webauthn_credentials.
+// It is the passkey or security key registered by user, which can be used to login without password.
+type WebAuthnCredential struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"user_id"`
+	CredentialID string     `json:"credential_id"` // base64url of the credential id from authenticator
+	PublicKey    []byte     `json:"public_key"`    // in COSE_Key format
+	SignCount    int64      `json:"sign_count"`    // signature counter of the last login, to detect cloned authenticator
+	Name         string     `json:"name"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// WebAuthnSession is a data structure that resemble column in table webauthn_sessions.
+// It keeps the challenge of registration or login ceremony until the browser sends the response.
+type WebAuthnSession struct {
+	ID            int64      `json:"id"`
+	ChallengeHash string     `json:"challenge_hash"`
+	Ceremony      string     `json:"ceremony"` // registration or login
+	UserID        *int64     `json:"user_id"`  // empty when login using discoverable credential
+	Nonce         string     `json:"nonce"`    // OpenID Connect nonce from login request
+	UsedAt        *time.Time `json:"used_at"`
+	ExpiredAt     time.Time  `json:"expired_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -2,18 +2,64 @@ package auth
 
 // Payload is a data carried by JWT token
 type Payload struct {
-	ID        string `json:"id"`       // required, id of this user
-	Username  string `json:"username"` // required, name of this user
-	IssuedAt  int64  `json:"iss"`      // token creation date, epoch time in seconds value (10 character)
-	NotBefore int64  `json:"nbf"`      // token valid start date, if token used before this time, it will contain error, epoch time in seconds value (10 character)
-	ExpiredAt int64  `json:"exp"`      // token expiration date, epoch time in seconds value (10 character)
+	ID              string   `json:"id"`                    // required, id of this user, or client id when the token is issued to service client
+	Username        string   `json:"username"`              // required, name of this user or service client
+	ClientID        string   `json:"client_id,omitempty"`   // only set when the token is issued to service client using client_credentials grant
+	AuthorizedParty string   `json:"azp,omitempty"`         // client id of OAuth client which gets this user token using authorization code grant
+	Scope           string   `json:"scope,omitempty"`       // space separated scope allowed by the user, only set together with AuthorizedParty
+	Roles           []string `json:"roles,omitempty"`       // role names of this user when the token is issued
+	Permissions     []string `json:"permissions,omitempty"` // permission names from all roles of this user when the token is issued
+	Version         int64    `json:"ver,omitempty"`         // token version of this user, token is rejected when user changes the password
+	JTI             string   `json:"jti"`                   // unique id of this token, used to revoke the token before it expired
+	IssuedAt        int64    `json:"iss"`                   // token creation date, epoch time in seconds value (10 character)
+	NotBefore       int64    `json:"nbf"`                   // token valid start date, if token used before this time, it will contain error, epoch time in seconds value (10 character)
+	ExpiredAt       int64    `json:"exp"`                   // token expiration date, epoch time in seconds value (10 character)
+}
+
+// HasRole returns true if this token has the role
+func (payload *Payload) HasRole(role string) bool {
+	for _, name := range payload.Roles {
+		if name == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasPermission returns true if this token has the permission
+func (payload *Payload) HasPermission(permission string) bool {
+	for _, name := range payload.Permissions {
+		if name == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IDTokenPayload is the claims of OpenID Connect ID token.
+// See https://openid.net/specs/openid-connect-core-1_0.html#IDToken
+type IDTokenPayload struct {
+	Issuer            string `json:"iss"`                          // required, url of this server
+	Subject           string `json:"sub"`                          // required, id of this user
+	Audience          string `json:"aud"`                          // required, client id which this token is intended for
+	ExpiredAt         int64  `json:"exp"`                          // required, epoch time in seconds value (10 character)
+	IssuedAt          int64  `json:"iat"`                          // required, epoch time in seconds value (10 character)
+	AuthTime          int64  `json:"auth_time"`                    // time when user enters the password, epoch time in seconds value (10 character)
+	Nonce             string `json:"nonce,omitempty"`              // value sent by client in authentication request, to mitigate replay attack
+	Name              string `json:"name,omitempty"`               // full name of this user
+	PreferredUsername string `json:"preferred_username,omitempty"` // username of this user
+	UpdatedAt         int64  `json:"updated_at,omitempty"`         // time when the profile is last changed, epoch time in seconds value (10 character)
 }
 
 // Auth is an higher abstraction level of authorization method.
 // ValidateToken method: to check if a token is valid or not, and
 // GenerateToken method: to generate token based on jwt payload
+// GenerateIDToken method: to generate OpenID Connect ID token
 // By this interface, you can easily change the JWT 3rd party library if it doesn't meet your needs.
 type Auth interface {
 	GenerateToken(payload *Payload, secretKey string) (token string, err error)
 	ValidateToken(token string, secretKey string) (payload *Payload, err error)
+	GenerateIDToken(payload *IDTokenPayload, secretKey string) (token string, err error)
 }
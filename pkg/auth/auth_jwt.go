@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -14,6 +16,11 @@ type JwtPayload struct {
 	Payload
 }
 
+// JwtIDTokenPayload wraps IDTokenPayload, so it can be used as jwt.Claims
+type JwtIDTokenPayload struct {
+	IDTokenPayload
+}
+
 // Jwt will implements Auth interface using library github.com/dgrijalva/jwt-go.
 type Jwt struct {
 }
@@ -62,26 +69,72 @@ func (jwtPayload JwtPayload) Valid() error {
 	return nil
 }
 
+// Valid is a method required by jwt.Claims set (github.com/dgrijalva/jwt-go).
+func (jwtPayload JwtIDTokenPayload) Valid() error {
+	if strings.TrimSpace(jwtPayload.Issuer) == "" {
+		return fmt.Errorf("iss must contains value")
+	}
+
+	if strings.TrimSpace(jwtPayload.Subject) == "" {
+		return fmt.Errorf("sub must contains value")
+	}
+
+	if strings.TrimSpace(jwtPayload.Audience) == "" {
+		return fmt.Errorf("aud must contains value")
+	}
+
+	if jwtPayload.ExpiredAt <= time.Now().Unix() {
+		return fmt.Errorf("token is expired")
+	}
+
+	return nil
+}
+
 // GenerateToken will generate jwt token using inputted payload
 func (authJwt *Jwt) GenerateToken(payload *Payload, secretKey string) (token string, err error) {
-	jwtToken := jwt.New(jwt.SigningMethodHS256)
+	return authJwt.sign(JwtPayload{Payload: *payload}, secretKey)
+}
+
+// GenerateIDToken will generate OpenID Connect ID token using inputted payload
+func (authJwt *Jwt) GenerateIDToken(payload *IDTokenPayload, secretKey string) (token string, err error) {
+	jwtPayload := JwtIDTokenPayload{IDTokenPayload: *payload}
+	if err = jwtPayload.Valid(); err != nil {
+		return
+	}
+
+	return authJwt.sign(jwtPayload, secretKey)
+}
 
-	// generate token using HS256
+// sign generates token using HS256
+func (authJwt *Jwt) sign(claims jwt.Claims, secretKey string) (token string, err error) {
+	jwtToken := jwt.New(jwt.SigningMethodHS256)
 	jwtToken.Header = map[string]interface{}{
 		"alg": jwt.SigningMethodHS256.Name,
+		"kid": SecretKeyID(secretKey),
 	}
 
-	jwtToken.Claims = JwtPayload{
-		Payload: *payload,
-	}
+	jwtToken.Claims = claims
 
 	token, err = jwtToken.SignedString([]byte(secretKey)) // sign with secret key
 	return
 }
 
+// SecretKeyID returns the kid of HS256 secret key. It is derived from sha256 of the secret, so it changes when the secret changes.
+// Only a part of the hash is used, it is only to tell which secret is used, not to validate the token.
+func SecretKeyID(secretKey string) string {
+	sum := sha256.Sum256([]byte(secretKey))
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
 // ValidateToken implements validating jwt token using secret key and return payload
 func (authJwt *Jwt) ValidateToken(token string, secretKey string) (payload *Payload, err error) {
 	jwtToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		// Don't forget to validate the alg is what you expect. We only use HS256,
+		// otherwise attacker can send token signed using other algorithm.
+		if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+
 		// hmacSampleSecret is a []byte containing your secret, e.g. []byte("my_secret_key")
 		return []byte(secretKey), nil
 	})
@@ -90,13 +143,11 @@ func (authJwt *Jwt) ValidateToken(token string, secretKey string) (payload *Payl
 		return nil, err
 	}
 
-	// Don't forget to validate the alg is what you expect. We use HMAC algorithm.
-	// _, ok := jwtToken.Method.(*jwt.SigningMethodHMAC)
-	// if !ok {
-	// 	err = fmt.Errorf("unexpected signing method: %v", jwtToken.Header["alg"])
-	// 	return nil, err
-	// }
+	return payloadFromToken(jwtToken)
+}
 
+// payloadFromToken converts the claims of parsed and verified token into Payload
+func payloadFromToken(jwtToken *jwt.Token) (payload *Payload, err error) {
 	claims, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok {
 		err = fmt.Errorf("token is not valid payload")
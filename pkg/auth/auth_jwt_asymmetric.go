@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// JwtAsymmetric implements Auth interface using private key to sign and public key to validate the token.
+// This way, other services only need our public key to validate the token.
+// The secretKey parameter in GenerateToken and ValidateToken is ignored.
+//
+// Token is signed using one signing key, but it can be validated using several keys.
+// So, when we rotate the key, token signed by the old key is still valid until it expired.
+type JwtAsymmetric struct {
+	signingKey crypto.Signer
+	keyID      string          // kid of signing key
+	keys       map[string]*Key // all accepted keys, including the signing key, by kid
+	keyIDs     []string        // order of keys, the signing key first
+}
+
+// NewJwtRSAAuth creates Auth which sign the token using RS256
+func NewJwtRSAAuth(privateKey *rsa.PrivateKey) (auth Auth, err error) {
+	return NewJwtKeySetAuth(privateKey)
+}
+
+// NewJwtECDSAAuth creates Auth which sign the token using ES256, ES384 or ES512 depends on the key curve.
+func NewJwtECDSAAuth(privateKey *ecdsa.PrivateKey) (auth Auth, err error) {
+	return NewJwtKeySetAuth(privateKey)
+}
+
+// NewJwtEdDSAAuth creates Auth which sign the token using EdDSA with Ed25519 key
+func NewJwtEdDSAAuth(privateKey ed25519.PrivateKey) (auth Auth, err error) {
+	return NewJwtKeySetAuth(privateKey)
+}
+
+// NewJwtKeySetAuth creates Auth which sign the token using signingKey.
+// Token signed by signingKey or one of verifyKeys is accepted in ValidateToken,
+// verifyKeys is used to keep the retiring keys when rotating the signing key.
+func NewJwtKeySetAuth(signingKey crypto.Signer, verifyKeys ...crypto.PublicKey) (auth Auth, err error) {
+	key, err := NewKey(signingKey.Public())
+	if err != nil {
+		return
+	}
+
+	authJwt := &JwtAsymmetric{
+		signingKey: signingKey,
+		keyID:      key.ID,
+		keys:       map[string]*Key{key.ID: key},
+		keyIDs:     []string{key.ID},
+	}
+
+	for _, verifyKey := range verifyKeys {
+		key, err := NewKey(verifyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, exist := authJwt.keys[key.ID]; exist {
+			continue
+		}
+
+		authJwt.keys[key.ID] = key
+		authJwt.keyIDs = append(authJwt.keyIDs, key.ID)
+	}
+
+	auth = authJwt
+	return
+}
+
+// NewJwtAuthFromPEMFile reads PEM encoded RSA, ECDSA or Ed25519 private key,
+// and creates Auth with signing method based on the key type.
+// verifyKeyPaths is the list of public (or private) key files of retiring keys, which still accepted in ValidateToken.
+func NewJwtAuthFromPEMFile(path string, verifyKeyPaths ...string) (auth Auth, err error) {
+	pemBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	privateKey, err := ParsePrivateKeyPEM(pemBytes)
+	if err != nil {
+		return
+	}
+
+	var verifyKeys []crypto.PublicKey
+	for _, verifyKeyPath := range verifyKeyPaths {
+		verifyKey, err := LoadPublicKeyPEMFile(verifyKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		verifyKeys = append(verifyKeys, verifyKey)
+	}
+
+	return NewJwtKeySetAuth(privateKey, verifyKeys...)
+}
+
+// ParsePrivateKeyPEM parses private key in PKCS #1 (RSA PRIVATE KEY), SEC 1 (EC PRIVATE KEY) or PKCS #8 (PRIVATE KEY) format.
+func ParsePrivateKeyPEM(pemBytes []byte) (privateKey crypto.Signer, err error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		err = fmt.Errorf("no pem block found")
+		return
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+
+		return signer, nil
+	default:
+		err = fmt.Errorf("unsupported pem block type %s", block.Type)
+		return
+	}
+}
+
+// GenerateToken will generate jwt token using inputted payload
+func (authJwt *JwtAsymmetric) GenerateToken(payload *Payload, secretKey string) (token string, err error) {
+	return authJwt.sign(JwtPayload{Payload: *payload})
+}
+
+// GenerateIDToken will generate OpenID Connect ID token using inputted payload
+func (authJwt *JwtAsymmetric) GenerateIDToken(payload *IDTokenPayload, secretKey string) (token string, err error) {
+	jwtPayload := JwtIDTokenPayload{IDTokenPayload: *payload}
+	if err = jwtPayload.Valid(); err != nil {
+		return
+	}
+
+	return authJwt.sign(jwtPayload)
+}
+
+// sign generates token using the signing key, with kid header
+func (authJwt *JwtAsymmetric) sign(claims jwt.Claims) (token string, err error) {
+	method := authJwt.keys[authJwt.keyID].Method
+	jwtToken := jwt.New(method)
+	jwtToken.Header = map[string]interface{}{
+		"alg": method.Alg(),
+		"kid": authJwt.keyID,
+	}
+
+	jwtToken.Claims = claims
+
+	token, err = jwtToken.SignedString(authJwt.signingKey) // sign with private key
+	return
+}
+
+// ValidateToken validates jwt token using public key and return payload
+func (authJwt *JwtAsymmetric) ValidateToken(token string, secretKey string) (payload *Payload, err error) {
+	jwtToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		// token issued before we use kid is signed using the signing key
+		keyID, _ := t.Header["kid"].(string)
+		if keyID == "" {
+			keyID = authJwt.keyID
+		}
+
+		key, ok := authJwt.keys[keyID]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id: %v", t.Header["kid"])
+		}
+
+		// only accept the same alg as the key is used for
+		if t.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+
+		return key.PublicKey, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return payloadFromToken(jwtToken)
+}
+
+// KeySet returns all accepted public keys in JWK format, the signing key first.
+func (authJwt *JwtAsymmetric) KeySet() JSONWebKeySet {
+	keySet := JSONWebKeySet{
+		Keys: make([]JSONWebKey, 0, len(authJwt.keyIDs)),
+	}
+
+	for _, keyID := range authJwt.keyIDs {
+		keySet.Keys = append(keySet.Keys, authJwt.keys[keyID].JWK())
+	}
+
+	return keySet
+}
@@ -0,0 +1,285 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+)
+
+func newTestPayload() *auth.Payload {
+	return &auth.Payload{
+		ID:        "1",
+		Username:  "John Doe",
+		JTI:       "f3b0c442",
+		IssuedAt:  time.Now().Unix(),
+		NotBefore: time.Now().Unix(),
+		ExpiredAt: time.Now().Add(2 * time.Minute).Unix(),
+	}
+}
+
+func TestJwtAsymmetric_GenerateAndValidateToken(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaAuth, err := auth.NewJwtRSAAuth(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecdsaAuth, err := auth.NewJwtECDSAAuth(ecdsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ed25519Auth, err := auth.NewJwtEdDSAAuth(ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authByAlg := map[string]auth.Auth{
+		"RS256": rsaAuth,
+		"ES256": ecdsaAuth,
+		"EdDSA": ed25519Auth,
+	}
+
+	convey.Convey("Generate and validate token using asymmetric key", t, func() {
+		for alg, authJwt := range authByAlg {
+			alg, authJwt := alg, authJwt
+			convey.Convey("When all value is good using "+alg, func() {
+				inputPayload := newTestPayload()
+
+				jwtToken, err := authJwt.GenerateToken(inputPayload, "")
+				convey.So(err, convey.ShouldBeNil)
+
+				outputPayload, err := authJwt.ValidateToken(jwtToken, "")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(outputPayload, convey.ShouldResemble, inputPayload)
+			})
+		}
+
+		convey.Convey("When token is signed using other key", func() {
+			jwtToken, err := authByAlg["ES256"].GenerateToken(newTestPayload(), "")
+			convey.So(err, convey.ShouldBeNil)
+
+			outputPayload, err := authByAlg["RS256"].ValidateToken(jwtToken, "")
+			convey.So(outputPayload, convey.ShouldBeNil)
+			convey.So(err.Error(), convey.ShouldStartWith, "unknown key id")
+		})
+
+		convey.Convey("When token is signed using HS256 with the public key as secret", func() {
+			publicKeyBytes, err := x509.MarshalPKIXPublicKey(rsaKey.Public())
+			convey.So(err, convey.ShouldBeNil)
+
+			// attacker uses our kid, so the public key is chosen
+			rsaKeyID := authByAlg["RS256"].(auth.KeySetProvider).KeySet().Keys[0].Kid
+			publicKeyPem := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes})
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.JwtPayload{Payload: *newTestPayload()})
+			token.Header["kid"] = rsaKeyID
+
+			jwtToken, err := token.SignedString(publicKeyPem)
+			convey.So(err, convey.ShouldBeNil)
+
+			outputPayload, err := authByAlg["RS256"].ValidateToken(jwtToken, string(publicKeyPem))
+			convey.So(outputPayload, convey.ShouldBeNil)
+			convey.So(err.Error(), convey.ShouldResemble, "unexpected signing method: HS256")
+		})
+
+		convey.Convey("When token has no kid and signed using other key", func() {
+			_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+			convey.So(err, convey.ShouldBeNil)
+
+			token := jwt.NewWithClaims(auth.SigningMethodEd25519, auth.JwtPayload{Payload: *newTestPayload()})
+			jwtToken, err := token.SignedString(otherKey)
+			convey.So(err, convey.ShouldBeNil)
+
+			outputPayload, err := authByAlg["EdDSA"].ValidateToken(jwtToken, "")
+			convey.So(outputPayload, convey.ShouldBeNil)
+			convey.So(err.Error(), convey.ShouldResemble, "signature is invalid")
+		})
+	})
+}
+
+func TestNewJwtAuthFromPEMFile(t *testing.T) {
+	t.Parallel()
+
+	writePem := func(block *pem.Block) string {
+		file, err := ioutil.TempFile("", "jwt-key-*.pem")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+
+		if err := pem.Encode(file, block); err != nil {
+			t.Fatal(err)
+		}
+
+		return file.Name()
+	}
+
+	convey.Convey("Load private key from pem file", t, func() {
+		convey.Convey("When the file contains PKCS #1 RSA key", func() {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			convey.So(err, convey.ShouldBeNil)
+
+			path := writePem(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+			defer os.Remove(path)
+
+			authJwt, err := auth.NewJwtAuthFromPEMFile(path)
+			convey.So(err, convey.ShouldBeNil)
+
+			expected, err := auth.NewJwtRSAAuth(key)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(authJwt, convey.ShouldResemble, expected)
+		})
+
+		convey.Convey("When the file contains SEC 1 EC key", func() {
+			key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+			convey.So(err, convey.ShouldBeNil)
+
+			keyBytes, err := x509.MarshalECPrivateKey(key)
+			convey.So(err, convey.ShouldBeNil)
+
+			path := writePem(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+			defer os.Remove(path)
+
+			authJwt, err := auth.NewJwtAuthFromPEMFile(path)
+			convey.So(err, convey.ShouldBeNil)
+
+			jwtToken, err := authJwt.GenerateToken(newTestPayload(), "")
+			convey.So(err, convey.ShouldBeNil)
+
+			_, err = authJwt.ValidateToken(jwtToken, "")
+			convey.So(err, convey.ShouldBeNil)
+		})
+
+		convey.Convey("When the file contains PKCS #8 Ed25519 key", func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			convey.So(err, convey.ShouldBeNil)
+
+			keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+			convey.So(err, convey.ShouldBeNil)
+
+			path := writePem(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+			defer os.Remove(path)
+
+			authJwt, err := auth.NewJwtAuthFromPEMFile(path)
+			convey.So(err, convey.ShouldBeNil)
+
+			expected, err := auth.NewJwtEdDSAAuth(key)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(authJwt, convey.ShouldResemble, expected)
+		})
+
+		convey.Convey("When the file is not a pem file", func() {
+			file, err := ioutil.TempFile("", "jwt-key-*.pem")
+			convey.So(err, convey.ShouldBeNil)
+			file.WriteString("not a pem")
+			file.Close()
+			defer os.Remove(file.Name())
+
+			authJwt, err := auth.NewJwtAuthFromPEMFile(file.Name())
+			convey.So(authJwt, convey.ShouldBeNil)
+			convey.So(err.Error(), convey.ShouldResemble, "no pem block found")
+		})
+	})
+}
+
+func TestJwtAsymmetric_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldAuth, err := auth.NewJwtECDSAAuth(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	convey.Convey("Rotate the signing key", t, func() {
+		rotatedAuth, err := auth.NewJwtKeySetAuth(newKey, oldKey.Public())
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("Token signed by retiring key is still valid", func() {
+			inputPayload := newTestPayload()
+			jwtToken, err := oldAuth.GenerateToken(inputPayload, "")
+			convey.So(err, convey.ShouldBeNil)
+
+			outputPayload, err := rotatedAuth.ValidateToken(jwtToken, "")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(outputPayload, convey.ShouldResemble, inputPayload)
+		})
+
+		convey.Convey("Token is signed using the new key with its kid", func() {
+			jwtToken, err := rotatedAuth.GenerateToken(newTestPayload(), "")
+			convey.So(err, convey.ShouldBeNil)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(jwtToken, jwt.MapClaims{})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(parsed.Header["alg"], convey.ShouldEqual, "EdDSA")
+
+			keySet := rotatedAuth.(auth.KeySetProvider).KeySet()
+			convey.So(len(keySet.Keys), convey.ShouldEqual, 2)
+			convey.So(parsed.Header["kid"], convey.ShouldEqual, keySet.Keys[0].Kid)
+			convey.So(keySet.Keys[0].Kty, convey.ShouldEqual, "OKP")
+			convey.So(keySet.Keys[1].Kty, convey.ShouldEqual, "EC")
+
+			// old auth doesn't know the new key
+			_, err = oldAuth.ValidateToken(jwtToken, "")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldStartWith, "unknown key id")
+		})
+	})
+}
+
+func TestKey_JWK(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Convert public key into JWK", t, func() {
+		convey.Convey("The kid is RFC 7638 thumbprint", func() {
+			// example key from RFC 7638 section 3.1
+			n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+			convey.So(err, convey.ShouldBeNil)
+
+			key, err := auth.NewKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(key.ID, convey.ShouldEqual, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs")
+			convey.So(key.JWK().E, convey.ShouldEqual, "AQAB")
+			convey.So(key.JWK().Alg, convey.ShouldEqual, "RS256")
+		})
+	})
+}
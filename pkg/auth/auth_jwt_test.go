@@ -39,6 +39,49 @@ func TestGenerateAndValidateTokenAtBestCondition(t *testing.T) {
 			convey.So(outputPayload, convey.ShouldResemble, inputPayload)
 		})
 
+		convey.Convey("When payload has jti", func() {
+			inputPayload := &auth.Payload{
+				ID:        "1",
+				Username:  "John Doe",
+				JTI:       "f3b0c442",
+				IssuedAt:  time.Now().Unix(),
+				NotBefore: time.Now().Unix(),
+				ExpiredAt: time.Now().Add(2 * time.Minute).Unix(),
+			}
+
+			jwtToken, err := authJwt.GenerateToken(inputPayload, secretKey)
+			convey.So(err, convey.ShouldBeNil)
+
+			outputPayload, err := authJwt.ValidateToken(jwtToken, secretKey)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(outputPayload, convey.ShouldResemble, inputPayload)
+			convey.So(outputPayload.JTI, convey.ShouldEqual, "f3b0c442")
+		})
+
+		convey.Convey("When payload has roles and permissions", func() {
+			inputPayload := &auth.Payload{
+				ID:          "1",
+				Username:    "John Doe",
+				Roles:       []string{"admin"},
+				Permissions: []string{"users:read", "users:write"},
+				JTI:         "f3b0c442",
+				IssuedAt:    time.Now().Unix(),
+				NotBefore:   time.Now().Unix(),
+				ExpiredAt:   time.Now().Add(2 * time.Minute).Unix(),
+			}
+
+			jwtToken, err := authJwt.GenerateToken(inputPayload, secretKey)
+			convey.So(err, convey.ShouldBeNil)
+
+			outputPayload, err := authJwt.ValidateToken(jwtToken, secretKey)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(outputPayload, convey.ShouldResemble, inputPayload)
+			convey.So(outputPayload.HasRole("admin"), convey.ShouldBeTrue)
+			convey.So(outputPayload.HasRole("user"), convey.ShouldBeFalse)
+			convey.So(outputPayload.HasPermission("users:write"), convey.ShouldBeTrue)
+			convey.So(outputPayload.HasPermission("users:delete"), convey.ShouldBeFalse)
+		})
+
 		convey.Convey("When secret key is different", func() {
 			inputPayload := &auth.Payload{
 				ID:        "1",
@@ -102,7 +145,7 @@ func TestJwt_ValidateToken(t *testing.T) {
 			// test the function
 			jwtPayload, err := authJwt.ValidateToken(tokenString, secretKey)
 			convey.So(jwtPayload, convey.ShouldBeNil)
-			convey.So(err.Error(), convey.ShouldResemble, "key is of invalid type")
+			convey.So(err.Error(), convey.ShouldResemble, "unexpected signing method: ES256")
 		})
 
 		convey.Convey("Token is expired", func() {
@@ -114,3 +157,49 @@ func TestJwt_ValidateToken(t *testing.T) {
 
 	})
 }
+
+func TestJwt_GenerateIDToken(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Generate ID token", t, func() {
+		secretKey := "abc"
+		authJwt := auth.NewJwtAuth()
+
+		convey.Convey("When all value is good", func() {
+			inputPayload := &auth.IDTokenPayload{
+				Issuer:    "http://localhost:8000",
+				Subject:   "1",
+				Audience:  "frontend",
+				ExpiredAt: time.Now().Add(2 * time.Minute).Unix(),
+				IssuedAt:  time.Now().Unix(),
+				AuthTime:  time.Now().Unix(),
+				Nonce:     "n-0S6_WzA2Mj",
+			}
+
+			idToken, err := authJwt.GenerateIDToken(inputPayload, secretKey)
+			convey.So(err, convey.ShouldBeNil)
+
+			token, err := jwt.Parse(idToken, func(t *jwt.Token) (interface{}, error) {
+				return []byte(secretKey), nil
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			claims := token.Claims.(jwt.MapClaims)
+			convey.So(claims["iss"], convey.ShouldEqual, "http://localhost:8000")
+			convey.So(claims["sub"], convey.ShouldEqual, "1")
+			convey.So(claims["aud"], convey.ShouldEqual, "frontend")
+			convey.So(claims["nonce"], convey.ShouldEqual, "n-0S6_WzA2Mj")
+			convey.So(claims["auth_time"], convey.ShouldEqual, float64(inputPayload.AuthTime))
+		})
+
+		convey.Convey("When audience is empty", func() {
+			idToken, err := authJwt.GenerateIDToken(&auth.IDTokenPayload{
+				Issuer:    "http://localhost:8000",
+				Subject:   "1",
+				ExpiredAt: time.Now().Add(2 * time.Minute).Unix(),
+			}, secretKey)
+			convey.So(idToken, convey.ShouldBeEmpty)
+			convey.So(err.Error(), convey.ShouldResemble, "aud must contains value")
+		})
+	})
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// JSONWebKey is a public key in JWK format (RFC 7517).
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA public exponent
+	Crv string `json:"crv,omitempty"` // EC or OKP curve name
+	X   string `json:"x,omitempty"`   // EC x coordinate or OKP public key
+	Y   string `json:"y,omitempty"`   // EC y coordinate
+}
+
+// JSONWebKeySet is the document served in /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeySetProvider is implemented by Auth which signs the token using asymmetric key,
+// so the public keys can be published for the other services.
+type KeySetProvider interface {
+	KeySet() JSONWebKeySet
+}
+
+// Key is a public key which is accepted to validate the token.
+type Key struct {
+	ID        string // kid, the RFC 7638 thumbprint of the key
+	Method    jwt.SigningMethod
+	PublicKey crypto.PublicKey
+}
+
+// NewKey creates Key with signing method based on the key type.
+func NewKey(publicKey crypto.PublicKey) (key *Key, err error) {
+	var method jwt.SigningMethod
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			method = jwt.SigningMethodES256
+		case elliptic.P384():
+			method = jwt.SigningMethodES384
+		case elliptic.P521():
+			method = jwt.SigningMethodES512
+		default:
+			err = fmt.Errorf("unsupported ecdsa curve %s", publicKey.Curve.Params().Name)
+			return
+		}
+	case ed25519.PublicKey:
+		method = SigningMethodEd25519
+	default:
+		err = fmt.Errorf("unsupported public key type %T", publicKey)
+		return
+	}
+
+	key = &Key{
+		Method:    method,
+		PublicKey: publicKey,
+	}
+
+	key.ID, err = key.thumbprint()
+	return
+}
+
+// JWK returns this key in JWK format.
+func (key *Key) JWK() JSONWebKey {
+	jwk := JSONWebKey{
+		Kid: key.ID,
+		Use: "sig",
+		Alg: key.Method.Alg(),
+	}
+
+	switch publicKey := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		// coordinate must be padded to the curve size
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = publicKey.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(padLeft(publicKey.X.Bytes(), size))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(padLeft(publicKey.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	}
+
+	return jwk
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint, which is used as the kid.
+func (key *Key) thumbprint() (string, error) {
+	jwk := key.JWK()
+
+	var members string
+	switch jwk.Kty {
+	case "RSA":
+		members = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.Kty, jwk.N)
+	case "EC":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, jwk.Crv, jwk.Kty, jwk.X, jwk.Y)
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Crv, jwk.Kty, jwk.X)
+	default:
+		return "", fmt.Errorf("unsupported key type %T", key.PublicKey)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// ParsePublicKeyPEM parses public key in PKIX (PUBLIC KEY) or PKCS #1 (RSA PUBLIC KEY) format.
+// Private key is accepted too, in that case the public part of the key is returned.
+func ParsePublicKeyPEM(pemBytes []byte) (publicKey crypto.PublicKey, err error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		err = fmt.Errorf("no pem block found")
+		return
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		privateKey, err := ParsePrivateKeyPEM(pemBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return privateKey.Public(), nil
+	}
+}
+
+// LoadPublicKeyPEMFile reads public key from file, see ParsePublicKeyPEM for the supported format.
+func LoadPublicKeyPEMFile(path string) (publicKey crypto.PublicKey, err error) {
+	pemBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	return ParsePublicKeyPEM(pemBytes)
+}
+
+func padLeft(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
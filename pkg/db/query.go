@@ -1,7 +1,17 @@
 package db
 
+import "context"
+
+// Query runs the sql using the context, so the query is cancelled when the request is cancelled or timed out
 type Query interface {
-	Raw(dst interface{}, sql string, args ...interface{}) (err error)
-	Exec(sql string, args ...interface{}) (err error)
+	// Raw and Exec use ctx, except for tx inside RunInTx: there ctx is ignored and the queries use the context
+	// passed to RunInTx, since the transaction is bound to the context which begins it.
+	Raw(ctx context.Context, dst interface{}, sql string, args ...interface{}) (err error)
+	Exec(ctx context.Context, sql string, args ...interface{}) (err error)
+
+	// RunInTx runs fn in a transaction, it is committed when fn returns nil and rolled back otherwise.
+	// Queries inside fn must use tx, the error returned by fn is returned as is.
+	// ctx of RunInTx cancels the whole transaction, use it for the queries inside fn too.
+	RunInTx(ctx context.Context, fn func(tx Query) error) (err error)
 	Migrate() error
 }
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"time"
 
 	"fmt"
@@ -14,7 +15,6 @@ import (
 )
 
 var logger = log.With().Str("pkg", "db").Logger()
-var goPgConnection *pg.DB
 
 // NewGoPgQuery will create new connection and returns 3 output,
 // 1. connection to database, this should not be used other than to close the connection
@@ -30,7 +30,6 @@ func NewGoPgQuery(config *Config) (dbConn *pg.DB, query Query, err error) {
 	dbOptions.IdleTimeout = time.Duration(5) * time.Second
 
 	dbConn = pg.Connect(dbOptions)
-	goPgConnection = dbConn
 
 	if config.Debug {
 		dbConn.OnQueryProcessed(func(event *pg.QueryProcessedEvent) {
@@ -49,29 +48,44 @@ func NewGoPgQuery(config *Config) (dbConn *pg.DB, query Query, err error) {
 	}
 
 	// using implemented interface
-	query = &QueryGoPg{
-		config: config,
-	}
+	query = NewQueryGoPg(dbConn, config)
 	return
 }
 
 // QueryGoPg implements Query interface with github.com/go-pg/pg connection
 type QueryGoPg struct {
 	config *Config
+	db     *pg.DB
+}
+
+// NewQueryGoPg uses the connection which is already opened, so several databases can be used in one process.
+// The config is only used to run the migration.
+func NewQueryGoPg(dbConn *pg.DB, config *Config) *QueryGoPg {
+	return &QueryGoPg{
+		config: config,
+		db:     dbConn,
+	}
 }
 
 // Raw will query to Postgres using raw sql and map the result into dst.
-func (q *QueryGoPg) Raw(dst interface{}, sql string, args ...interface{}) (err error) {
-	_, err = goPgConnection.Query(dst, sql, args...)
+func (q *QueryGoPg) Raw(ctx context.Context, dst interface{}, sql string, args ...interface{}) (err error) {
+	_, err = q.db.WithContext(ctx).Query(dst, sql, args...)
 	return
 }
 
 // Exec will do query to Postgres without returning values
-func (q *QueryGoPg) Exec(sql string, args ...interface{}) (err error) {
-	_, err = goPgConnection.Exec(sql, args...)
+func (q *QueryGoPg) Exec(ctx context.Context, sql string, args ...interface{}) (err error) {
+	_, err = q.db.WithContext(ctx).Exec(sql, args...)
 	return
 }
 
+// RunInTx begins the transaction using the context, so the transaction is rolled back when the request is cancelled
+func (q *QueryGoPg) RunInTx(ctx context.Context, fn func(tx Query) error) (err error) {
+	return q.db.WithContext(ctx).RunInTransaction(func(tx *pg.Tx) error {
+		return fn(&txGoPg{tx: tx})
+	})
+}
+
 func (q *QueryGoPg) Migrate() error {
 	s := bindata.Resource(
 		migrations.AssetNames(),
@@ -110,3 +124,30 @@ func (q *QueryGoPg) Migrate() error {
 
 	return m.Up()
 }
+
+// txGoPg implements Query interface inside the transaction of go-pg.
+// Queries use the context of RunInTx, since go-pg transaction is bound to the context of the connection which begins it.
+type txGoPg struct {
+	tx *pg.Tx
+}
+
+// Raw ignores ctx, the query uses the context of RunInTx
+func (q *txGoPg) Raw(ctx context.Context, dst interface{}, sql string, args ...interface{}) (err error) {
+	_, err = q.tx.Query(dst, sql, args...)
+	return
+}
+
+// Exec ignores ctx, the query uses the context of RunInTx
+func (q *txGoPg) Exec(ctx context.Context, sql string, args ...interface{}) (err error) {
+	_, err = q.tx.Exec(sql, args...)
+	return
+}
+
+// RunInTx inside transaction runs fn in the same transaction, so function which uses RunInTx can be called inside other transaction
+func (q *txGoPg) RunInTx(ctx context.Context, fn func(tx Query) error) (err error) {
+	return fn(q)
+}
+
+func (q *txGoPg) Migrate() error {
+	return fmt.Errorf("migration cannot run inside transaction")
+}
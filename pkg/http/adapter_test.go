@@ -0,0 +1,169 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// conformanceHandler uses all of Request, so both adapters must give the same response
+func conformanceHandler(ctx context.Context, req http.Request) http.Response {
+	form := &struct {
+		Name string   `json:"name" form:"name" validate:"required"`
+		Age  int      `json:"age" form:"age"`
+		Tags []string `json:"tags" form:"tags"`
+	}{}
+
+	if err := req.ValidatedBind(form); err != nil {
+		return http.NewErrorResponse(err)
+	}
+
+	if form.Name == "nil" {
+		return nil
+	}
+
+	var userID int64
+	if req.User() != nil {
+		userID = req.User().ID
+	}
+
+	resp := http.NewJsonResponse(201, map[string]interface{}{
+		"name":         form.Name,
+		"age":          form.Age,
+		"tags":         form.Tags,
+		"id":           req.GetParam("id"),
+		"ip":           req.ClientIP(),
+		"content_type": req.ContentType(),
+		"user_id":      userID,
+	})
+	resp.Header().Set("X-Request-Id", "abc")
+	return resp
+}
+
+func setUserMiddleware(next http.Handler) http.Handler {
+	return func(ctx context.Context, req http.Request) http.Response {
+		req.SetUser(&model.User{ID: 7})
+		return next(ctx, req)
+	}
+}
+
+func TestAdapterConformance(t *testing.T) {
+	t.Parallel()
+
+	handler := setUserMiddleware(conformanceHandler)
+
+	ginRouter := gin.New()
+	ginRouter.Any("/items/:id", http.WrapGin(context.Background(), handler))
+
+	mux := stdhttp.NewServeMux()
+	mux.Handle("/items/", http.WrapStdlib(handler, func(req *stdhttp.Request, key string) string {
+		if key != "id" {
+			return ""
+		}
+		return strings.TrimPrefix(req.URL.Path, "/items/")
+	}))
+
+	multipartBody := func() (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("name", "alice")
+		writer.WriteField("age", "30")
+		writer.WriteField("tags", "a")
+		writer.WriteField("tags", "b")
+		writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	cases := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        func() (*bytes.Buffer, string)
+		header      map[string]string
+	}{
+		{name: "json body", method: "POST", target: "/items/42", contentType: "application/json; charset=utf-8",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString(`{"name": "alice", "age": 30, "tags": ["a", "b"]}`), ""
+			}},
+		{name: "url encoded body", method: "POST", target: "/items/42", contentType: "application/x-www-form-urlencoded",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString("name=alice&age=30&tags=a&tags=b"), ""
+			}},
+		{name: "multipart body", method: "POST", target: "/items/42", body: multipartBody},
+		{name: "query string", method: "GET", target: "/items/42?name=alice&age=30&tags=a&tags=b"},
+		{name: "forwarded client ip", method: "GET", target: "/items/1?name=alice",
+			header: map[string]string{"X-Forwarded-For": "203.0.113.7"}},
+		{name: "invalid json", method: "POST", target: "/items/42", contentType: "application/json",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString(`{"name": `), ""
+			}},
+		{name: "validation error", method: "POST", target: "/items/42", contentType: "application/json",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString(`{"age": 30}`), ""
+			}},
+		{name: "nil response", method: "GET", target: "/items/42?name=nil"},
+	}
+
+	newRequest := func(method, target, contentType string, body func() (*bytes.Buffer, string), header map[string]string) *stdhttp.Request {
+		var req *stdhttp.Request
+		if body == nil {
+			req = httptest.NewRequest(method, target, nil)
+		} else {
+			buf, multipartType := body()
+			req = httptest.NewRequest(method, target, buf)
+			if multipartType != "" {
+				contentType = multipartType
+			}
+		}
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		return req
+	}
+
+	convey.Convey("WrapGin and WrapStdlib give the same response", t, func() {
+		for _, c := range cases {
+			c := c
+			convey.Convey(c.name, func() {
+				ginRecorder := httptest.NewRecorder()
+				ginRouter.ServeHTTP(ginRecorder, newRequest(c.method, c.target, c.contentType, c.body, c.header))
+
+				stdlibRecorder := httptest.NewRecorder()
+				mux.ServeHTTP(stdlibRecorder, newRequest(c.method, c.target, c.contentType, c.body, c.header))
+
+				convey.So(stdlibRecorder.Code, convey.ShouldEqual, ginRecorder.Code)
+				convey.So(stdlibRecorder.Body.String(), convey.ShouldEqual, ginRecorder.Body.String())
+				convey.So(stdlibRecorder.Header().Get("Content-Type"), convey.ShouldEqual, ginRecorder.Header().Get("Content-Type"))
+				convey.So(stdlibRecorder.Header().Get("X-Request-Id"), convey.ShouldEqual, ginRecorder.Header().Get("X-Request-Id"))
+			})
+		}
+	})
+
+	convey.Convey("WrapStdlib binds the request", t, func() {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, newRequest("POST", "/items/42", "", multipartBody, nil))
+
+		convey.So(recorder.Code, convey.ShouldEqual, 201)
+		convey.So(recorder.Body.String(), convey.ShouldEqual,
+			`{"age":30,"content_type":"multipart/form-data","id":"42","ip":"192.0.2.1","name":"alice","tags":["a","b"],"user_id":7}`)
+	})
+}
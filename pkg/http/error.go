@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"net/http"
+)
+
+// APIError is the error sent to the client as {"error": {"code": ..., "message": ..., "details": [...]}}.
+// Code is stable, so the client can switch on it, while message is for human and may be changed anytime.
+type APIError struct {
+	Status  int          `json:"-"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError tells which field of the request is not valid and why
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Common errors, use WithMessage or WithDetails to give more information about the error.
+var (
+	ErrInvalidBody      = NewAPIError(http.StatusBadRequest, "invalid_body", "request body cannot be parsed")
+	ErrValidation       = NewAPIError(http.StatusUnprocessableEntity, "validation_failed", "request is not valid")
+	ErrUnauthorized     = NewAPIError(http.StatusUnauthorized, "unauthorized", "valid access token is required")
+	ErrForbidden        = NewAPIError(http.StatusForbidden, "forbidden", "you are not allowed to access this resource")
+	ErrNotFound         = NewAPIError(http.StatusNotFound, "not_found", "resource not found")
+	ErrRouteNotFound    = NewAPIError(http.StatusNotFound, "route_not_found", "route not found")
+	ErrMethodNotAllowed = NewAPIError(http.StatusMethodNotAllowed, "method_not_allowed", "method for this route not found")
+	ErrConflict         = NewAPIError(http.StatusConflict, "conflict", "resource already exists")
+	ErrRateLimited      = NewAPIError(http.StatusTooManyRequests, "rate_limited", "too many requests, please try again later")
+	ErrInternal         = NewAPIError(http.StatusInternalServerError, "internal_server_error", "internal server error")
+)
+
+// NewAPIError creates error with http status code, code and default message
+func NewAPIError(status int, code, message string) *APIError {
+	return &APIError{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (err *APIError) Error() string {
+	return err.Code + ": " + err.Message
+}
+
+// WithMessage returns copy of the error using other message, the code is not changed
+func (err *APIError) WithMessage(message string) *APIError {
+	copied := *err
+	copied.Message = message
+	return &copied
+}
+
+// WithDetails returns copy of the error with the field errors added
+func (err *APIError) WithDetails(details ...FieldError) *APIError {
+	copied := *err
+	copied.Details = append(append([]FieldError{}, err.Details...), details...)
+	return &copied
+}
+
+// Response returns json response using the status of the error
+func (err *APIError) Response() Response {
+	return NewJsonResponse(err.Status, map[string]interface{}{
+		"error": err,
+	})
+}
+
+// NewErrorResponse returns the response of APIError, other error is sent as internal server error
+func NewErrorResponse(err error) Response {
+	if apiError, ok := err.(*APIError); ok {
+		return apiError.Response()
+	}
+
+	return ErrInternal.WithMessage(err.Error()).Response()
+}
+
+// NotFoundHandler is used when no route matches the request
+func NotFoundHandler(ctx context.Context, req Request) Response {
+	return ErrRouteNotFound.Response()
+}
+
+// MethodNotAllowedHandler is used when the route exists, but not for the request method
+func MethodNotAllowedHandler(ctx context.Context, req Request) Response {
+	return ErrMethodNotAllowed.Response()
+}
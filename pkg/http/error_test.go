@@ -0,0 +1,51 @@
+package http_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Write APIError as json response", t, func() {
+		convey.Convey("Details are listed under error", func() {
+			resp := http.ErrValidation.WithDetails(http.FieldError{
+				Field:   "username",
+				Code:    "required",
+				Message: "username cannot be empty",
+			}).Response()
+
+			body, err := resp.Body()
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(resp.StatusCode(), convey.ShouldEqual, 422)
+			convey.So(string(body), convey.ShouldEqual,
+				`{"error":{"code":"validation_failed","message":"request is not valid","details":[{"field":"username","code":"required","message":"username cannot be empty"}]}}`)
+		})
+
+		convey.Convey("Common error is not changed by WithMessage and WithDetails", func() {
+			http.ErrNotFound.WithMessage("user not found").WithDetails(http.FieldError{Field: "id"})
+
+			body, err := http.ErrNotFound.Response().Body()
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(body), convey.ShouldEqual, `{"error":{"code":"not_found","message":"resource not found"}}`)
+		})
+
+		convey.Convey("Other error is sent as internal server error", func() {
+			resp := http.NewErrorResponse(fmt.Errorf("connection refused"))
+
+			body, err := resp.Body()
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(resp.StatusCode(), convey.ShouldEqual, 500)
+			convey.So(string(body), convey.ShouldEqual, `{"error":{"code":"internal_server_error","message":"connection refused"}}`)
+		})
+
+		convey.Convey("APIError keeps its status and code", func() {
+			resp := http.NewErrorResponse(http.ErrRateLimited)
+			convey.So(resp.StatusCode(), convey.ShouldEqual, 429)
+		})
+	})
+}
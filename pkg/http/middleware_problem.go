@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"mime"
+	"strings"
+)
+
+// ErrorFormat is the body of error response
+type ErrorFormat string
+
+const (
+	// ErrorFormatEnvelope writes {"error": {"code": ..., "message": ...}}, unless the client only accepts application/problem+json
+	ErrorFormatEnvelope ErrorFormat = "envelope"
+
+	// ErrorFormatProblem always writes RFC 7807 application/problem+json
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+const problemContentType = "application/problem+json"
+
+// ProblemDetails returns middleware which turns json error response into RFC 7807 problem details,
+// when the format is ErrorFormatProblem or the Accept header asks for application/problem+json.
+// The error code is used as problem type under typeBaseURL, for example https://example.com/problems/validation_failed.
+// When typeBaseURL is empty, the type is about:blank. The code and details are kept as extension members:
+//
+//	{"type": "about:blank", "title": "Unprocessable Entity", "status": 422, "detail": "request is not valid", "instance": "/api/v1/user/register",
+//	 "code": "validation_failed", "details": [{"field": "username", "code": "required", "message": "username cannot be empty"}]}
+func ProblemDetails(format ErrorFormat, typeBaseURL string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) Response {
+			resp := next(ctx, req)
+			if resp == nil || (format != ErrorFormatProblem && !acceptsProblem(req)) {
+				return resp
+			}
+
+			problem, ok := problemFromResponse(resp)
+			if !ok {
+				return resp
+			}
+
+			if problem.Extensions["code"] != nil && typeBaseURL != "" {
+				problem.Type = strings.TrimSuffix(typeBaseURL, "/") + "/" + problem.Extensions["code"].(string)
+			}
+
+			problem.Instance = req.RawRequest().URL.Path
+
+			problemResp := NewProblemResponse(problem)
+			for key, values := range resp.Header() {
+				problemResp.Header()[key] = values
+			}
+
+			return problemResp
+		}
+	}
+}
+
+// acceptsProblem returns true when application/problem+json is listed before application/json in Accept header
+func acceptsProblem(req Request) bool {
+	accept := req.RawRequest().Header.Get("Accept")
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case problemContentType:
+			return true
+		case "application/json", "*/*", "application/*":
+			return false
+		}
+	}
+
+	return false
+}
+
+// problemFromResponse reads the error envelope of json response, false when the response is not error
+func problemFromResponse(resp Response) (problem Problem, ok bool) {
+	jsonResp, ok := resp.(*jsonResponse)
+	if !ok || jsonResp.statusCode < 400 {
+		return problem, false
+	}
+
+	data, ok := jsonResp.data.(map[string]interface{})
+	if !ok {
+		return problem, false
+	}
+
+	problem = Problem{
+		Status:     jsonResp.statusCode,
+		Extensions: map[string]interface{}{},
+	}
+
+	switch envelope := data["error"].(type) {
+	case *APIError:
+		problem.Detail = envelope.Message
+		problem.Extensions["code"] = envelope.Code
+		if len(envelope.Details) > 0 {
+			problem.Extensions["details"] = envelope.Details
+		}
+	case map[string]interface{}:
+		for key, value := range envelope {
+			switch key {
+			case "message":
+				problem.Detail, _ = value.(string)
+			case "code":
+				if code, isString := value.(string); isString && code != "" {
+					problem.Extensions["code"] = code
+				}
+			default:
+				problem.Extensions[key] = value
+			}
+		}
+	default:
+		return problem, false
+	}
+
+	return problem, true
+}
@@ -0,0 +1,93 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+func TestProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	serve := func(format http.ErrorFormat, handler http.Handler, accept string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.GET("/resource", http.WrapGin(context.Background(), http.ProblemDetails(format, "https://example.com/problems")(handler)))
+
+		req := httptest.NewRequest("GET", "/resource", nil)
+		req.Header.Set("Accept", accept)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	validationFailed := func(ctx context.Context, req http.Request) http.Response {
+		return http.ErrValidation.WithDetails(http.FieldError{Field: "name", Code: "required", Message: "name cannot be empty"}).Response()
+	}
+
+	convey.Convey("Write error response as RFC 7807 problem details", t, func() {
+		convey.Convey("When the format is problem", func() {
+			recorder := serve(http.ErrorFormatProblem, validationFailed, "application/json")
+			convey.So(recorder.Code, convey.ShouldEqual, 422)
+			convey.So(recorder.Header().Get("Content-Type"), convey.ShouldStartWith, "application/problem+json")
+
+			var body map[string]interface{}
+			convey.So(json.Unmarshal(recorder.Body.Bytes(), &body), convey.ShouldBeNil)
+			convey.So(body, convey.ShouldResemble, map[string]interface{}{
+				"type":     "https://example.com/problems/validation_failed",
+				"title":    "Unprocessable Entity",
+				"status":   float64(422),
+				"detail":   "request is not valid",
+				"instance": "/resource",
+				"code":     "validation_failed",
+				"details": []interface{}{
+					map[string]interface{}{"field": "name", "code": "required", "message": "name cannot be empty"},
+				},
+			})
+		})
+
+		convey.Convey("When the client asks it in Accept header", func() {
+			recorder := serve(http.ErrorFormatEnvelope, validationFailed, "application/problem+json, application/json")
+			convey.So(recorder.Header().Get("Content-Type"), convey.ShouldStartWith, "application/problem+json")
+		})
+
+		convey.Convey("Headers and other members of map error are kept", func() {
+			recorder := serve(http.ErrorFormatProblem, func(ctx context.Context, req http.Request) http.Response {
+				resp := http.NewJsonResponse(429, map[string]interface{}{
+					"error": map[string]interface{}{
+						"code":        "too_many_attempts",
+						"message":     "too many failed login, please try again later",
+						"retry_after": 2,
+					},
+				})
+				resp.Header().Set("Retry-After", "2")
+				return resp
+			}, "")
+
+			var body map[string]interface{}
+			convey.So(json.Unmarshal(recorder.Body.Bytes(), &body), convey.ShouldBeNil)
+			convey.So(recorder.Code, convey.ShouldEqual, 429)
+			convey.So(recorder.Header().Get("Retry-After"), convey.ShouldEqual, "2")
+			convey.So(body["type"], convey.ShouldEqual, "https://example.com/problems/too_many_attempts")
+			convey.So(body["retry_after"], convey.ShouldEqual, 2)
+		})
+	})
+
+	convey.Convey("Keep the error envelope", t, func() {
+		convey.Convey("When the client accepts application/json", func() {
+			recorder := serve(http.ErrorFormatEnvelope, validationFailed, "application/json, application/problem+json")
+			convey.So(recorder.Header().Get("Content-Type"), convey.ShouldStartWith, "application/json")
+		})
+
+		convey.Convey("When the response is not error", func() {
+			recorder := serve(http.ErrorFormatProblem, func(ctx context.Context, req http.Request) http.Response {
+				return http.NewJsonResponse(200, map[string]interface{}{"ok": true})
+			}, "")
+			convey.So(recorder.Body.String(), convey.ShouldEqual, `{"ok":true}`)
+		})
+	})
+}
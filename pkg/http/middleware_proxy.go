@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies is the networks of reverse proxies in front of this server.
+// X-Forwarded-For and X-Real-Ip headers are only read when the request comes from one of them,
+// otherwise any client can send the header to pretend to be other ip address.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses ip addresses or CIDR ranges, like "10.0.0.0/8" or "127.0.0.1"
+func ParseTrustedProxies(addresses []string) (proxies TrustedProxies, err error) {
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+
+		if !strings.Contains(address, "/") {
+			ip := net.ParseIP(address)
+			if ip == nil {
+				return nil, fmt.Errorf("trusted proxy %s is not valid ip address", address)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(address)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %s is not valid: %s", address, err.Error())
+		}
+
+		proxies = append(proxies, network)
+	}
+
+	return
+}
+
+// trusts returns true when the address is one of the proxies
+func (proxies TrustedProxies) trusts(address string) bool {
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientIP returns the ip address of the client. When the remote address is a trusted proxy, X-Forwarded-For is read
+// from the right and the first address which is not a trusted proxy is the client, since the left addresses
+// are sent by the client and can be anything. X-Real-Ip is used when there is no X-Forwarded-For.
+func (proxies TrustedProxies) ClientIP(req *http.Request) string {
+	clientIP := remoteIP(req)
+	if !proxies.trusts(clientIP) {
+		return clientIP
+	}
+
+	forwarded := false
+	forwardedFor := strings.Split(strings.Join(req.Header["X-Forwarded-For"], ","), ",")
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(forwardedFor[i])
+		if address == "" {
+			continue
+		}
+
+		forwarded, clientIP = true, address
+		if !proxies.trusts(address) {
+			return clientIP
+		}
+	}
+
+	if realIP := strings.TrimSpace(req.Header.Get("X-Real-Ip")); !forwarded && realIP != "" {
+		return realIP
+	}
+
+	// every address is trusted proxy, the left one is the nearest to the client
+	return clientIP
+}
+
+// TrustProxies returns middleware which reads Request.ClientIP from X-Forwarded-For or X-Real-Ip header
+// when the request comes from one of the proxies. Without it, ClientIP is always the remote address.
+// Chain it before the middleware which uses the client ip, like RateLimit with KeyByIP:
+//
+//	http.ChainMiddleware(http.TrustProxies(proxies), http.RateLimit("register", limiter, http.KeyByIP))
+func TrustProxies(proxies TrustedProxies) Middleware {
+	return func(next Handler) Handler {
+		if len(proxies) == 0 {
+			return next
+		}
+
+		return func(ctx context.Context, req Request) Response {
+			return next(ctx, &proxiedRequest{
+				Request:  req,
+				clientIP: proxies.ClientIP(req.RawRequest()),
+			})
+		}
+	}
+}
+
+// proxiedRequest replaces the client ip of the request behind trusted proxy
+type proxiedRequest struct {
+	Request
+	clientIP string
+}
+
+func (req *proxiedRequest) ClientIP() string {
+	return req.clientIP
+}
@@ -0,0 +1,85 @@
+package http_test
+
+import (
+	"context"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+func TestTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Read client ip from the header of trusted proxy only", t, func() {
+		proxies, err := http.ParseTrustedProxies([]string{"10.0.0.0/8", " 127.0.0.1", ""})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(proxies), convey.ShouldEqual, 2)
+
+		newRequest := func(remoteAddr string, header map[string]string) *stdhttp.Request {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = remoteAddr
+			for key, value := range header {
+				req.Header.Set(key, value)
+			}
+			return req
+		}
+
+		convey.Convey("When the request doesn't come from the proxy, the header is ignored", func() {
+			req := newRequest("203.0.113.7:1234", map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-Ip": "198.51.100.2"})
+			convey.So(proxies.ClientIP(req), convey.ShouldEqual, "203.0.113.7")
+		})
+
+		convey.Convey("When the request comes from the proxy, the nearest address which is not a proxy is the client", func() {
+			req := newRequest("10.0.0.2:1234", map[string]string{"X-Forwarded-For": "198.51.100.9, 203.0.113.7, 10.0.0.1"})
+			convey.So(proxies.ClientIP(req), convey.ShouldEqual, "203.0.113.7")
+		})
+
+		convey.Convey("When the proxy only sets X-Real-Ip", func() {
+			req := newRequest("127.0.0.1:1234", map[string]string{"X-Real-Ip": "203.0.113.7"})
+			convey.So(proxies.ClientIP(req), convey.ShouldEqual, "203.0.113.7")
+		})
+
+		convey.Convey("When the address is not valid", func() {
+			_, err := http.ParseTrustedProxies([]string{"10.0.0.0/33"})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = http.ParseTrustedProxies([]string{"proxy.local"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("Middleware replaces the client ip of the handler", func() {
+			clientIP := func(proxies http.TrustedProxies, req *stdhttp.Request) (ip string) {
+				router := gin.New()
+				router.GET("/", http.WrapGin(req.Context(), http.TrustProxies(proxies)(func(_ context.Context, req http.Request) http.Response {
+					ip = req.ClientIP()
+					return http.NewJsonResponse(200, map[string]interface{}{})
+				})))
+				router.ServeHTTP(httptest.NewRecorder(), req)
+				return
+			}
+
+			header := map[string]string{"X-Forwarded-For": "203.0.113.7"}
+			convey.So(clientIP(proxies, newRequest("10.0.0.2:1234", header)), convey.ShouldEqual, "203.0.113.7")
+			convey.So(clientIP(nil, newRequest("10.0.0.2:1234", header)), convey.ShouldEqual, "10.0.0.2")
+		})
+
+		convey.Convey("WrapStdlib doesn't trust the header unless the middleware is chained", func() {
+			clientIP := func(proxies http.TrustedProxies, req *stdhttp.Request) (ip string) {
+				http.WrapStdlib(http.TrustProxies(proxies)(func(_ context.Context, req http.Request) http.Response {
+					ip = req.ClientIP()
+					return http.NewJsonResponse(200, map[string]interface{}{})
+				})).ServeHTTP(httptest.NewRecorder(), req)
+				return
+			}
+
+			header := map[string]string{"X-Forwarded-For": "203.0.113.7", "X-Real-Ip": "203.0.113.8"}
+			convey.So(clientIP(nil, newRequest("198.51.100.1:1234", header)), convey.ShouldEqual, "198.51.100.1")
+			convey.So(clientIP(proxies, newRequest("198.51.100.1:1234", header)), convey.ShouldEqual, "198.51.100.1")
+			convey.So(clientIP(proxies, newRequest("10.0.0.2:1234", header)), convey.ShouldEqual, "203.0.113.7")
+		})
+	})
+}
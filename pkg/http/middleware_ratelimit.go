@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/ratelimit"
+)
+
+var logger = log.With().Str("pkg", "http").Logger()
+
+// KeyFunc returns the key of the request to count in rate limit, empty key means the request is not limited.
+type KeyFunc func(ctx context.Context, req Request) string
+
+// KeyByIP counts the request by client ip address
+func KeyByIP(ctx context.Context, req Request) string {
+	return "ip:" + req.ClientIP()
+}
+
+// KeyByUser counts the request by current user, so it must be chained after the middleware which sets the user.
+// Request without user is counted by client ip address.
+func KeyByUser(ctx context.Context, req Request) string {
+	if user := req.User(); user != nil && user.ID != 0 {
+		return "user:" + strconv.FormatInt(user.ID, 10)
+	}
+
+	return KeyByIP(ctx, req)
+}
+
+// RateLimit returns middleware which rejects the request with 429 when the key has too many requests.
+// The name is added to the key, so limiters of different routes can share the same store.
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers are added to the response:
+//
+//	http.ChainMiddleware(protectedMiddleware, http.RateLimit("profile", limiter, http.KeyByUser))
+//
+// When the store fails, the request is not limited, so rate limit doesn't make the whole service down.
+func RateLimit(name string, limiter *ratelimit.Limiter, keyFunc KeyFunc) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) Response {
+			key := keyFunc(ctx, req)
+			if key == "" {
+				return next(ctx, req)
+			}
+
+			result, err := limiter.Allow(name+":"+key, time.Now())
+			if err != nil {
+				logger.Error().Err(err).Str("name", name).Msg("fail counting rate limit")
+				return next(ctx, req)
+			}
+
+			var resp Response
+			if result.Allowed {
+				resp = next(ctx, req)
+			} else {
+				resp = ErrRateLimited.Response()
+			}
+
+			if resp == nil {
+				return nil
+			}
+
+			reset := strconv.FormatInt(int64(math.Ceil(result.Reset.Seconds())), 10)
+			resp.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			resp.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			resp.Header().Set("RateLimit-Reset", reset)
+			if !result.Allowed {
+				resp.Header().Set("Retry-After", reset)
+			}
+
+			return resp
+		}
+	}
+}
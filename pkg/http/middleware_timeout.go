@@ -0,0 +1,32 @@
+package http
+
+import (
+	"context"
+	"time"
+)
+
+// ErrTimeout is returned when the handler fails because it takes longer than the timeout
+var ErrTimeout = NewAPIError(503, "timeout", "request takes too long, please try again later")
+
+// Timeout returns middleware which cancels the context of the handler after the duration, so database queries are stopped.
+// Zero duration means no timeout. The handler still decides the response, but when it fails with status 5xx
+// after the deadline, the response is replaced with ErrTimeout since the failure is caused by the cancellation.
+func Timeout(timeout time.Duration) Middleware {
+	return func(next Handler) Handler {
+		if timeout <= 0 {
+			return next
+		}
+
+		return func(ctx context.Context, req Request) Response {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			resp := next(ctx, req)
+			if ctx.Err() == context.DeadlineExceeded && (resp == nil || resp.StatusCode() >= 500) {
+				return ErrTimeout.Response()
+			}
+
+			return resp
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package http_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+type contextKey struct{}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	waitCancelled := func(ctx context.Context, req http.Request) http.Response {
+		<-ctx.Done()
+		return http.ErrInternal.WithMessage(ctx.Err().Error()).Response()
+	}
+
+	convey.Convey("Cancel the handler context after timeout", t, func() {
+		convey.Convey("Failure after the deadline is sent as timeout", func() {
+			resp := http.Timeout(10*time.Millisecond)(waitCancelled)(context.Background(), nil)
+			convey.So(resp.StatusCode(), convey.ShouldEqual, 503)
+
+			body, err := resp.Body()
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(body), convey.ShouldContainSubstring, `"code":"timeout"`)
+		})
+
+		convey.Convey("Response before the deadline is not changed", func() {
+			resp := http.Timeout(time.Second)(func(ctx context.Context, req http.Request) http.Response {
+				_, hasDeadline := ctx.Deadline()
+				convey.So(hasDeadline, convey.ShouldBeTrue)
+				return http.NewJsonResponse(200, map[string]interface{}{})
+			})(context.Background(), nil)
+			convey.So(resp.StatusCode(), convey.ShouldEqual, 200)
+		})
+
+		convey.Convey("Zero timeout doesn't set deadline", func() {
+			http.Timeout(0)(func(ctx context.Context, req http.Request) http.Response {
+				_, hasDeadline := ctx.Deadline()
+				convey.So(hasDeadline, convey.ShouldBeFalse)
+				return nil
+			})(context.Background(), nil)
+		})
+	})
+
+	convey.Convey("WrapGin uses the context of the http request", t, func() {
+		serve := func(parent, requestCtx context.Context, handler http.Handler) *httptest.ResponseRecorder {
+			router := gin.New()
+			router.GET("/", http.WrapGin(parent, handler))
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil).WithContext(requestCtx))
+			return recorder
+		}
+
+		convey.Convey("Values of the request context are kept", func() {
+			requestCtx := context.WithValue(context.Background(), contextKey{}, "value")
+			recorder := serve(context.Background(), requestCtx, func(ctx context.Context, req http.Request) http.Response {
+				return http.NewJsonResponse(200, map[string]interface{}{"value": ctx.Value(contextKey{})})
+			})
+			convey.So(recorder.Body.String(), convey.ShouldEqual, `{"value":"value"}`)
+		})
+
+		convey.Convey("Handler is cancelled when the client disconnects", func() {
+			requestCtx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			recorder := serve(context.Background(), requestCtx, waitCancelled)
+			convey.So(recorder.Body.String(), convey.ShouldContainSubstring, context.Canceled.Error())
+		})
+
+		convey.Convey("Handler is cancelled when the parent is cancelled", func() {
+			parent, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(10*time.Millisecond, cancel)
+
+			recorder := serve(parent, context.Background(), waitCancelled)
+			convey.So(recorder.Body.String(), convey.ShouldContainSubstring, context.Canceled.Error())
+		})
+	})
+}
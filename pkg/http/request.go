@@ -1,7 +1,9 @@
 package http
 
 import (
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
 )
@@ -9,8 +11,22 @@ import (
 type Request interface {
 	ContentType() string
 	Bind(out interface{}) error
+	ValidatedBind(out interface{}) error // bind then Validate, the error is APIError which can be sent using NewErrorResponse
 	GetParam(key string) string
 	RawRequest() *http.Request
+	ClientIP() string  // ip address of the client, the remote address unless TrustProxies middleware reads it from the proxy header
 	User() *model.User // get the current user
 	SetUser(user *model.User)
+	Client() *model.ServiceClient // get the current service client, nil when the token is issued to user
+	SetClient(client *model.ServiceClient)
+}
+
+// remoteIP returns the ip address of the connection, without the port
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(req.RemoteAddr))
+	if err != nil {
+		return ""
+	}
+
+	return host
 }
@@ -2,65 +2,41 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
 )
 
-// WrapGin wraps a Handler and turns it into gin compatible handler
-// This method should be called with a fresh ctx
+// WrapGin wraps a Handler and turns it into gin compatible handler.
+// The handler context is the context of the http request, so it is cancelled when the client disconnects.
+// It is cancelled too when the parent is cancelled, for example when the server is shutting down.
 func WrapGin(parent context.Context, handler Handler) gin.HandlerFunc {
 	return func(ginContext *gin.Context) {
-		// create span
-		ctx := context.Background()
-		defer ctx.Done()
+		ctx, cancel := context.WithCancel(ginContext.Request.Context())
+		defer cancel()
+
+		if parent.Done() != nil {
+			go func() {
+				select {
+				case <-parent.Done():
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+		}
 
 		// create request and run the handler
 		var req = newGinRequest(ginContext)
-		resp := handler(ctx, req)
-
-		if resp == nil {
-			ginContext.JSON(http.StatusInternalServerError, map[string]interface{}{
-				"error": map[string]interface{}{
-					"code":    "internal_server_error",
-					"message": "nil response",
-					"data":    nil,
-				},
-			})
-			return
-		}
-
-		// get the body first
-		body, err := resp.Body()
-		if err != nil {
-			ginContext.JSON(http.StatusInternalServerError, map[string]interface{}{
-				"error": map[string]interface{}{
-					"code":    "internal_server_error",
-					"message": err.Error(),
-					"data":    nil,
-				},
-			})
-			return
-		}
-		// then write header
-		for k, v := range resp.Header() {
-			for _, h := range v {
-				ginContext.Writer.Header().Add(k, h)
-			}
-		}
-
-		ginContext.Writer.Header().Add("Content-Type", resp.ContentType())
-		ginContext.Writer.WriteHeader(resp.StatusCode())
-
-		// the last is writing the body
-		ginContext.Writer.Write(body)
+		writeResponse(ginContext.Writer, handler(ctx, req))
 	}
 }
 
 type ginRequest struct {
 	context *gin.Context
 	user    *model.User
+	client  *model.ServiceClient
 }
 
 func newGinRequest(context *gin.Context) (request Request) {
@@ -74,6 +50,15 @@ func (ginRequest *ginRequest) Bind(out interface{}) error {
 	return ginRequest.context.Bind(out)
 }
 
+func (ginRequest *ginRequest) ValidatedBind(out interface{}) error {
+	// ShouldBind doesn't write 400 status like Bind, the status follows the returned error
+	if err := ginRequest.context.ShouldBind(out); err != nil {
+		return ErrInvalidBody.WithMessage(fmt.Sprintf("fail when binding the payload: %s", err.Error()))
+	}
+
+	return Validate(out)
+}
+
 func (ginRequest *ginRequest) GetParam(key string) string {
 	return ginRequest.context.Param(key)
 }
@@ -90,6 +75,11 @@ func (ginRequest *ginRequest) RawRequest() *http.Request {
 	return ginRequest.context.Request
 }
 
+// ClientIP is the remote address, gin.Context.ClientIP is not used since it trusts X-Forwarded-For from anyone
+func (ginRequest *ginRequest) ClientIP() string {
+	return remoteIP(ginRequest.context.Request)
+}
+
 func (ginRequest *ginRequest) User() *model.User {
 	return ginRequest.user
 }
@@ -97,3 +87,11 @@ func (ginRequest *ginRequest) User() *model.User {
 func (ginRequest *ginRequest) SetUser(user *model.User) {
 	ginRequest.user = user
 }
+
+func (ginRequest *ginRequest) Client() *model.ServiceClient {
+	return ginRequest.client
+}
+
+func (ginRequest *ginRequest) SetClient(client *model.ServiceClient) {
+	ginRequest.client = client
+}
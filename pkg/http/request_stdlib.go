@@ -0,0 +1,222 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+)
+
+// maxMultipartMemory is the memory used to parse multipart form, the rest is stored in temporary files
+const maxMultipartMemory = 32 << 20
+
+// ParamFunc returns the path parameter of the request. It has the same signature as chi.URLParam,
+// and (*http.Request).PathValue of Go 1.22 can be used as func(req *http.Request, key string) string { return req.PathValue(key) }
+type ParamFunc func(req *http.Request, key string) string
+
+// WrapStdlib wraps a Handler and turns it into net/http handler, so it can be mounted in chi or http.ServeMux:
+//
+//	router.Method("POST", "/user/login", http.WrapStdlib(userHandler.LoginUserHandler))
+//	router.Method("GET", "/admin/users/{id}", http.WrapStdlib(adminMiddleware(adminHandler.GetUserHandler), chi.URLParam))
+//
+// paramFunc is optional, without it GetParam always returns empty string.
+// ClientIP is the remote address, chain TrustProxies when the router is behind a reverse proxy.
+func WrapStdlib(handler Handler, paramFunc ...ParamFunc) http.Handler {
+	var params ParamFunc
+	if len(paramFunc) > 0 {
+		params = paramFunc[0]
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, raw *http.Request) {
+		req := newStdlibRequest(raw, params)
+		writeResponse(writer, handler(raw.Context(), req))
+	})
+}
+
+type stdlibRequest struct {
+	request *http.Request
+	params  ParamFunc
+	user    *model.User
+	client  *model.ServiceClient
+}
+
+func newStdlibRequest(request *http.Request, params ParamFunc) (req Request) {
+	req = &stdlibRequest{
+		request: request,
+		params:  params,
+	}
+	return
+}
+
+// Bind decodes json body, or form values from query string, url encoded body or multipart body
+// into the fields with form tag, like gin does.
+func (stdlibRequest *stdlibRequest) Bind(out interface{}) error {
+	request := stdlibRequest.request
+	if request.Method != http.MethodGet && stdlibRequest.ContentType() == "application/json" {
+		if request.Body == nil {
+			return fmt.Errorf("invalid request")
+		}
+
+		return json.NewDecoder(request.Body).Decode(out)
+	}
+
+	if stdlibRequest.ContentType() == "multipart/form-data" {
+		if err := request.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return err
+		}
+	} else if err := request.ParseForm(); err != nil {
+		return err
+	}
+
+	return bindForm(out, request.Form)
+}
+
+func (stdlibRequest *stdlibRequest) ValidatedBind(out interface{}) error {
+	if err := stdlibRequest.Bind(out); err != nil {
+		return ErrInvalidBody.WithMessage(fmt.Sprintf("fail when binding the payload: %s", err.Error()))
+	}
+
+	return Validate(out)
+}
+
+func (stdlibRequest *stdlibRequest) GetParam(key string) string {
+	if stdlibRequest.params == nil {
+		return ""
+	}
+
+	return stdlibRequest.params(stdlibRequest.request, key)
+}
+
+func (stdlibRequest *stdlibRequest) ContentType() string {
+	mediaType, _, _ := mime.ParseMediaType(stdlibRequest.request.Header.Get("Content-Type"))
+	return mediaType
+}
+
+func (stdlibRequest *stdlibRequest) RawRequest() *http.Request {
+	return stdlibRequest.request
+}
+
+// ClientIP is the remote address, X-Forwarded-For and X-Real-Ip headers are only used through TrustProxies middleware
+func (stdlibRequest *stdlibRequest) ClientIP() string {
+	return remoteIP(stdlibRequest.request)
+}
+
+func (stdlibRequest *stdlibRequest) User() *model.User {
+	return stdlibRequest.user
+}
+
+func (stdlibRequest *stdlibRequest) SetUser(user *model.User) {
+	stdlibRequest.user = user
+}
+
+func (stdlibRequest *stdlibRequest) Client() *model.ServiceClient {
+	return stdlibRequest.client
+}
+
+func (stdlibRequest *stdlibRequest) SetClient(client *model.ServiceClient) {
+	stdlibRequest.client = client
+}
+
+// bindForm sets struct fields using form tag, or field name when there is no tag.
+// Only string, bool, number and slice of them are supported, like the forms in this project.
+func bindForm(out interface{}, form url.Values) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("form can only be bound into pointer of struct")
+	}
+
+	value = value.Elem()
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		values, ok := form[name]
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(fieldValue.Type(), len(values), len(values))
+			for j, formValue := range values {
+				if err := setFormValue(slice.Index(j), formValue); err != nil {
+					return fmt.Errorf("%s: %s", name, err.Error())
+				}
+			}
+
+			fieldValue.Set(slice)
+			continue
+		}
+
+		if err := setFormValue(fieldValue, values[0]); err != nil {
+			return fmt.Errorf("%s: %s", name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func setFormValue(value reflect.Value, formValue string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(formValue)
+	case reflect.Bool:
+		if formValue == "" {
+			formValue = "false"
+		}
+
+		parsed, err := strconv.ParseBool(formValue)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if formValue == "" {
+			formValue = "0"
+		}
+
+		parsed, err := strconv.ParseInt(formValue, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if formValue == "" {
+			formValue = "0"
+		}
+
+		parsed, err := strconv.ParseUint(formValue, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		if formValue == "" {
+			formValue = "0"
+		}
+
+		parsed, err := strconv.ParseFloat(formValue, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("type %s is not supported", value.Type())
+	}
+
+	return nil
+}
@@ -8,3 +8,30 @@ type Response interface {
 	Header() http.Header
 	ContentType() string
 }
+
+// writeResponse writes the response of Handler into ResponseWriter, it is used by all adapters
+func writeResponse(writer http.ResponseWriter, resp Response) {
+	if resp == nil {
+		resp = ErrInternal.WithMessage("nil response").Response()
+	}
+
+	// get the body first
+	body, err := resp.Body()
+	if err != nil {
+		resp = ErrInternal.WithMessage(err.Error()).Response()
+		body, _ = resp.Body()
+	}
+
+	// then write header
+	for k, v := range resp.Header() {
+		for _, h := range v {
+			writer.Header().Add(k, h)
+		}
+	}
+
+	writer.Header().Add("Content-Type", resp.ContentType())
+	writer.WriteHeader(resp.StatusCode())
+
+	// the last is writing the body
+	writer.Write(body)
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+)
+
+type htmlResponse struct {
+	statusCode int
+	body       []byte
+	header     http.Header
+}
+
+// NewHtmlResponse returns already rendered html page
+func NewHtmlResponse(statusCode int, body []byte) (response Response) {
+	response = &htmlResponse{
+		statusCode: statusCode,
+		body:       body,
+		header:     http.Header{},
+	}
+	return
+}
+
+func (htmlResponse *htmlResponse) StatusCode() int {
+	return htmlResponse.statusCode
+}
+
+func (htmlResponse *htmlResponse) Body() ([]byte, error) {
+	return htmlResponse.body, nil
+}
+
+func (htmlResponse *htmlResponse) Header() http.Header {
+	return htmlResponse.header
+}
+
+func (htmlResponse *htmlResponse) ContentType() string {
+	return "text/html; charset=utf-8"
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is the problem details of RFC 7807.
+// Extensions are written as top level members, next to the standard members.
+type Problem struct {
+	Type       string // uri of the problem type, about:blank when it is empty
+	Title      string // short summary of the problem type, status text when it is empty
+	Status     int
+	Detail     string // explanation of this occurrence of the problem
+	Instance   string // uri of this occurrence of the problem, for example the request path
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON writes the extensions first, so they cannot replace the standard members
+func (problem Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(problem.Extensions)+5)
+	for key, value := range problem.Extensions {
+		members[key] = value
+	}
+
+	members["type"] = problem.Type
+	if problem.Type == "" {
+		members["type"] = "about:blank"
+	}
+
+	members["title"] = problem.Title
+	if problem.Title == "" {
+		members["title"] = http.StatusText(problem.Status)
+	}
+
+	members["status"] = problem.Status
+	if problem.Detail != "" {
+		members["detail"] = problem.Detail
+	}
+
+	if problem.Instance != "" {
+		members["instance"] = problem.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+type problemResponse struct {
+	problem Problem
+	header  http.Header
+}
+
+// NewProblemResponse returns application/problem+json response, the status code is taken from the problem
+func NewProblemResponse(problem Problem) (response Response) {
+	response = &problemResponse{
+		problem: problem,
+		header:  http.Header{},
+	}
+	return
+}
+
+func (problemResponse *problemResponse) StatusCode() int {
+	return problemResponse.problem.Status
+}
+
+func (problemResponse *problemResponse) Body() ([]byte, error) {
+	return json.Marshal(problemResponse.problem)
+}
+
+func (problemResponse *problemResponse) Header() http.Header {
+	return problemResponse.header
+}
+
+func (problemResponse *problemResponse) ContentType() string {
+	return "application/problem+json; charset=utf-8"
+}
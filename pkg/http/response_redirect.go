@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+)
+
+type redirectResponse struct {
+	statusCode int
+	header     http.Header
+}
+
+// NewRedirectResponse redirects the client into location using 302 Found
+func NewRedirectResponse(location string) (response Response) {
+	header := http.Header{}
+	header.Set("Location", location)
+
+	response = &redirectResponse{
+		statusCode: http.StatusFound,
+		header:     header,
+	}
+	return
+}
+
+func (redirectResponse *redirectResponse) StatusCode() int {
+	return redirectResponse.statusCode
+}
+
+func (redirectResponse *redirectResponse) Body() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (redirectResponse *redirectResponse) Header() http.Header {
+	return redirectResponse.header
+}
+
+func (redirectResponse *redirectResponse) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
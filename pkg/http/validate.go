@@ -0,0 +1,196 @@
+package http
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// patterns caches compiled regexp of pattern rule
+var patterns sync.Map
+
+// Validate checks the struct fields using the rules in validate tag, and returns ErrValidation listing every field which fails.
+// The field name in the details is taken from json tag, then form tag. Rules are separated by comma:
+//
+//	Username string `json:"username" validate:"required,max=160"`
+//	Role     string `json:"role" validate:"enum=admin|member"`
+//	Code     string `json:"code" validate:"min=6,max=8,pattern=^[0-9a-z]+$"`
+//
+// Rules:
+//   - required: string cannot be empty or only spaces, other type cannot be zero value
+//   - min=N, max=N: number of characters of string, length of slice or map, or value of number
+//   - enum=a|b: string must be one of the values
+//   - email: string must be bare email address, without name
+//   - pattern=regexp: string must match the regexp, it must be the last rule since the regexp may contain comma
+//
+// Empty field which is not required is not checked by other rules.
+// Invalid rule is programming error, so it panics.
+func Validate(v interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("http: Validate needs struct, got %s", value.Kind()))
+	}
+
+	var details []FieldError
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok || field.PkgPath != "" {
+			continue
+		}
+
+		if detail, failed := validateField(fieldName(field), value.Field(i), tag); failed {
+			details = append(details, detail)
+		}
+	}
+
+	if len(details) > 0 {
+		return ErrValidation.WithDetails(details...)
+	}
+
+	return nil
+}
+
+// validateField returns the first rule which fails, so one field only has one detail
+func validateField(name string, value reflect.Value, tag string) (detail FieldError, failed bool) {
+	fail := func(code, message string) (FieldError, bool) {
+		return FieldError{Field: name, Code: code, Message: name + " " + message}, true
+	}
+
+	rules := splitRules(tag)
+	if isEmpty(value) {
+		for _, rule := range rules {
+			if rule == "required" {
+				return fail("required", "cannot be empty")
+			}
+		}
+
+		return
+	}
+
+	for _, rule := range rules {
+		ruleName, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			ruleName, param = rule[:i], rule[i+1:]
+		}
+
+		switch ruleName {
+		case "required":
+		case "min", "max":
+			limit, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				panic(fmt.Sprintf("http: %s of %s must be number", ruleName, name))
+			}
+
+			size, unit := measure(value)
+			if ruleName == "min" && size < limit {
+				return fail("min", fmt.Sprintf("must be at least %s%s", param, unit))
+			}
+
+			if ruleName == "max" && size > limit {
+				return fail("max", fmt.Sprintf("must be at most %s%s", param, unit))
+			}
+		case "enum":
+			allowed := strings.Split(param, "|")
+			found := false
+			for _, option := range allowed {
+				found = found || value.String() == option
+			}
+
+			if !found {
+				return fail("enum", fmt.Sprintf("must be one of %s", strings.Join(allowed, ", ")))
+			}
+		case "email":
+			address, err := mail.ParseAddress(value.String())
+			if err != nil || address.Address != value.String() {
+				return fail("email", "is not valid email address")
+			}
+		case "pattern":
+			if !compilePattern(param).MatchString(value.String()) {
+				return fail("pattern", "format is not valid")
+			}
+		default:
+			panic(fmt.Sprintf("http: unknown validation rule %s of %s", ruleName, name))
+		}
+	}
+
+	return
+}
+
+// splitRules splits the tag by comma, except in pattern rule
+func splitRules(tag string) (rules []string) {
+	for tag != "" {
+		if strings.HasPrefix(tag, "pattern=") {
+			return append(rules, tag)
+		}
+
+		i := strings.Index(tag, ",")
+		if i < 0 {
+			return append(rules, tag)
+		}
+
+		rules = append(rules, tag[:i])
+		tag = tag[i+1:]
+	}
+
+	return
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if compiled, ok := patterns.Load(pattern); ok {
+		return compiled.(*regexp.Regexp)
+	}
+
+	compiled := regexp.MustCompile(pattern)
+	patterns.Store(pattern, compiled)
+	return compiled
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	default:
+		return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+	}
+}
+
+// measure returns the size which is compared in min and max rules, and its unit for the message
+func measure(value reflect.Value) (size float64, unit string) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), " characters"
+	case reflect.Slice, reflect.Map:
+		return float64(value.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), ""
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), ""
+	default:
+		panic(fmt.Sprintf("http: min and max cannot be used for %s", value.Kind()))
+	}
+}
+
+// fieldName returns the name which is sent by the client
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
@@ -0,0 +1,75 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+)
+
+type validateForm struct {
+	Username string   `json:"username" validate:"required,min=3,max=8"`
+	Email    string   `form:"email" validate:"email"`
+	Role     string   `json:"role" validate:"enum=admin|member"`
+	Code     string   `json:"code" validate:"pattern=^[0-9]{2,6}$"`
+	Age      int      `json:"age" validate:"min=13"`
+	Scopes   []string `json:"scopes" validate:"required,max=2"`
+	Nonce    string   `json:"nonce"`
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Validate struct using validate tag", t, func() {
+		convey.Convey("When all fields are valid", func() {
+			err := http.Validate(&validateForm{
+				Username: "alice",
+				Email:    "alice@example.com",
+				Role:     "admin",
+				Code:     "123456",
+				Age:      20,
+				Scopes:   []string{"openid"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+		})
+
+		convey.Convey("Empty field is only checked by required rule", func() {
+			err := http.Validate(&validateForm{Username: "  ", Scopes: []string{"openid"}})
+			convey.So(err, convey.ShouldResemble, http.ErrValidation.WithDetails(
+				http.FieldError{Field: "username", Code: "required", Message: "username cannot be empty"},
+			))
+		})
+
+		convey.Convey("Every field which fails is listed", func() {
+			err := http.Validate(validateForm{
+				Username: "alexandria",
+				Email:    "Alice <alice@example.com>",
+				Role:     "owner",
+				Code:     "12,34",
+				Age:      12,
+				Scopes:   []string{"openid", "profile", "email"},
+			})
+			convey.So(err, convey.ShouldResemble, http.ErrValidation.WithDetails(
+				http.FieldError{Field: "username", Code: "max", Message: "username must be at most 8 characters"},
+				http.FieldError{Field: "email", Code: "email", Message: "email is not valid email address"},
+				http.FieldError{Field: "role", Code: "enum", Message: "role must be one of admin, member"},
+				http.FieldError{Field: "code", Code: "pattern", Message: "code format is not valid"},
+				http.FieldError{Field: "age", Code: "min", Message: "age must be at least 13"},
+				http.FieldError{Field: "scopes", Code: "max", Message: "scopes must be at most 2 items"},
+			))
+		})
+
+		convey.Convey("Length is counted in characters", func() {
+			err := http.Validate(&validateForm{Username: "日本語", Scopes: []string{"openid"}})
+			convey.So(err, convey.ShouldBeNil)
+		})
+
+		convey.Convey("Unknown rule panics", func() {
+			convey.So(func() {
+				http.Validate(&struct {
+					Name string `validate:"requred"`
+				}{Name: "alice"})
+			}, convey.ShouldPanic)
+		})
+	})
+}
@@ -0,0 +1,135 @@
+// Package lockout limits failed attempts, for example wrong password in login.
+// After some failures, next attempt must wait for a delay which is doubled on each failure,
+// and after too many failures the key is locked for a while.
+package lockout
+
+import "time"
+
+// Attempt is the failed attempts of a key
+type Attempt struct {
+	Failures     int
+	LastFailedAt time.Time
+}
+
+// Store keeps the failed attempts. Use memory store for a single server,
+// and postgres store when several servers must share the same count.
+type Store interface {
+	// Get returns the failures of the key, it is empty when the last failure is not after since.
+	Get(key string, since time.Time) (attempt Attempt, err error)
+
+	// Fail adds one failure at now and returns the updated count.
+	// When the last failure is not after since, the count starts again from one.
+	Fail(key string, now, since time.Time) (attempt Attempt, err error)
+
+	// Reserve adds one failure at now like Fail, but only when allow returns true for the current failures.
+	// The check and the update are one atomic step, so concurrent attempts cannot all pass the check
+	// before any of them is counted. Nothing is changed when allow returns false.
+	Reserve(key string, now, since time.Time, allow func(attempt Attempt) bool) (attempt Attempt, allowed bool, err error)
+
+	// Release removes one failure added by Reserve, when the attempt turns out to be correct.
+	Release(key string) error
+
+	// Reset removes the failures of the key.
+	Reset(key string) error
+}
+
+// Policy decides how long to wait after failures
+type Policy struct {
+	FreeAttempts    int           // failures allowed without any delay
+	Delay           time.Duration // delay after the first failure above FreeAttempts, it is doubled for each next failure
+	MaxFailures     int           // the key is locked after this number of failures
+	LockoutDuration time.Duration // how long the key is locked, failures are forgotten after this duration too
+}
+
+// Status is the result of checking a key
+type Status struct {
+	Locked     bool          // true when the key has too many failures, false when it only needs to wait for the delay
+	RetryAfter time.Duration // zero when the attempt is allowed
+}
+
+// Allowed returns true when the attempt can be made now
+func (status Status) Allowed() bool {
+	return status.RetryAfter <= 0
+}
+
+// Limiter applies the policy to the failures in the store
+type Limiter struct {
+	store  Store
+	policy Policy
+}
+
+// NewLimiter creates limiter, limiters with different policy can share the same store as long as the keys are different.
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{
+		store:  store,
+		policy: policy,
+	}
+}
+
+// Check returns whether the attempt for the key is allowed at now
+func (limiter *Limiter) Check(key string, now time.Time) (status Status, err error) {
+	attempt, err := limiter.store.Get(key, now.Add(-limiter.policy.LockoutDuration))
+	if err != nil {
+		return
+	}
+
+	return limiter.policy.status(attempt, now), nil
+}
+
+// Fail records failed attempt at now, and returns the status for the next attempt
+func (limiter *Limiter) Fail(key string, now time.Time) (status Status, err error) {
+	attempt, err := limiter.store.Fail(key, now, now.Add(-limiter.policy.LockoutDuration))
+	if err != nil {
+		return
+	}
+
+	return limiter.policy.status(attempt, now), nil
+}
+
+// Reserve counts the attempt as failure before it is verified, but only when the attempt is allowed at now.
+// The returned status is not allowed when the attempt must wait, and then it is not counted.
+// Call Release when the attempt turns out to be correct, or Reset to forget all failures.
+func (limiter *Limiter) Reserve(key string, now time.Time) (status Status, err error) {
+	allow := func(attempt Attempt) bool {
+		status = limiter.policy.status(attempt, now)
+		return status.Allowed()
+	}
+
+	_, _, err = limiter.store.Reserve(key, now, now.Add(-limiter.policy.LockoutDuration), allow)
+	return
+}
+
+// Release removes the failure counted by Reserve, so correct attempt is not counted
+func (limiter *Limiter) Release(key string) error {
+	return limiter.store.Release(key)
+}
+
+// Reset forgets the failures of the key, usually after the attempt succeeds
+func (limiter *Limiter) Reset(key string) error {
+	return limiter.store.Reset(key)
+}
+
+func (policy Policy) status(attempt Attempt, now time.Time) Status {
+	var until time.Time
+	var locked bool
+
+	switch {
+	case attempt.Failures >= policy.MaxFailures:
+		until = attempt.LastFailedAt.Add(policy.LockoutDuration)
+		locked = true
+	case attempt.Failures > policy.FreeAttempts:
+		delay := policy.LockoutDuration
+		if shift := uint(attempt.Failures - policy.FreeAttempts - 1); shift < 32 && policy.Delay<<shift < delay {
+			delay = policy.Delay << shift
+		}
+		until = attempt.LastFailedAt.Add(delay)
+	default:
+		return Status{}
+	}
+
+	if !until.After(now) {
+		return Status{}
+	}
+
+	return Status{Locked: locked, RetryAfter: until.Sub(now)}
+}
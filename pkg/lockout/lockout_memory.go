@@ -0,0 +1,105 @@
+package lockout
+
+import (
+	"sync"
+	"time"
+)
+
+// maxMemoryAttempts is the number of keys before we try to remove the old one
+const maxMemoryAttempts = 10000
+
+// memorySweepInterval is the minimum time between two removals, since each removal iterates all keys
+// and the keys may still be above maxMemoryAttempts after it when most of them are recent.
+const memorySweepInterval = time.Minute
+
+type memoryStore struct {
+	mutex       sync.Mutex
+	attempts    map[string]Attempt
+	lastSweptAt time.Time
+}
+
+// NewMemoryStore creates store which keeps the failures in memory, so it only works for a single server
+// and the failures are forgotten when the server restarts.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		attempts: map[string]Attempt{},
+	}
+}
+
+func (store *memoryStore) Get(key string, since time.Time) (attempt Attempt, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	attempt = store.attempts[key]
+	if !attempt.LastFailedAt.After(since) {
+		return Attempt{}, nil
+	}
+
+	return
+}
+
+func (store *memoryStore) Fail(key string, now, since time.Time) (attempt Attempt, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.fail(key, now, since), nil
+}
+
+func (store *memoryStore) Reserve(key string, now, since time.Time, allow func(attempt Attempt) bool) (attempt Attempt, allowed bool, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	attempt = store.attempts[key]
+	if !attempt.LastFailedAt.After(since) {
+		attempt = Attempt{}
+	}
+
+	if !allow(attempt) {
+		return attempt, false, nil
+	}
+
+	return store.fail(key, now, since), true, nil
+}
+
+func (store *memoryStore) Release(key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if attempt, ok := store.attempts[key]; ok && attempt.Failures > 0 {
+		attempt.Failures--
+		store.attempts[key] = attempt
+	}
+
+	return nil
+}
+
+// fail adds one failure, the mutex must be locked by the caller
+func (store *memoryStore) fail(key string, now, since time.Time) (attempt Attempt) {
+	if len(store.attempts) >= maxMemoryAttempts && now.Sub(store.lastSweptAt) >= memorySweepInterval {
+		for k, v := range store.attempts {
+			if !v.LastFailedAt.After(since) {
+				delete(store.attempts, k)
+			}
+		}
+
+		store.lastSweptAt = now
+	}
+
+	attempt = store.attempts[key]
+	if !attempt.LastFailedAt.After(since) {
+		attempt = Attempt{}
+	}
+
+	attempt.Failures++
+	attempt.LastFailedAt = now
+	store.attempts[key] = attempt
+	return attempt
+}
+
+func (store *memoryStore) Reset(key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.attempts, key)
+	return nil
+}
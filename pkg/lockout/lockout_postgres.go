@@ -0,0 +1,100 @@
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+)
+
+type postgresStore struct {
+	db db.Query
+}
+
+// NewPostgresStore creates store using failed_attempts table, so all servers share the same failures.
+// Queries don't use the request context, otherwise client can disconnect early so the failure is not recorded.
+func NewPostgresStore(db db.Query) Store {
+	return &postgresStore{
+		db: db,
+	}
+}
+
+func (store *postgresStore) Get(key string, since time.Time) (attempt Attempt, err error) {
+	var sqlGetAttempt = `
+		SELECT failures, last_failed_at FROM failed_attempts WHERE attempt_key = ? AND last_failed_at > ? LIMIT 1;
+	`
+
+	err = store.db.Raw(context.Background(), &attempt, sqlGetAttempt, key, since)
+	return
+}
+
+func (store *postgresStore) Fail(key string, now, since time.Time) (attempt Attempt, err error) {
+	// the count is updated in one statement, so failures from different servers at the same time are all counted
+	var sqlFailAttempt = `
+		INSERT INTO failed_attempts (attempt_key, failures, last_failed_at) VALUES (?, 1, ?)
+		ON CONFLICT (attempt_key) DO UPDATE SET
+			failures = CASE WHEN failed_attempts.last_failed_at > ? THEN failed_attempts.failures + 1 ELSE 1 END,
+			last_failed_at = EXCLUDED.last_failed_at
+		RETURNING failures, last_failed_at;
+	`
+
+	if err = store.db.Raw(context.Background(), &attempt, sqlFailAttempt, key, now, since); err != nil {
+		return
+	}
+
+	// clean up the failures which are already forgotten
+	err = store.db.Exec(context.Background(), "DELETE FROM failed_attempts WHERE last_failed_at <= ?;", since)
+	return
+}
+
+func (store *postgresStore) Reserve(key string, now, since time.Time, allow func(attempt Attempt) bool) (attempt Attempt, allowed bool, err error) {
+	// make sure the row exists, so there is something to lock
+	var sqlEnsureAttempt = `
+		INSERT INTO failed_attempts (attempt_key, failures, last_failed_at) VALUES (?, 0, ?) ON CONFLICT (attempt_key) DO NOTHING;
+	`
+
+	// the row is locked until the transaction ends, so other servers wait before checking the same key
+	var sqlLockAttempt = `
+		SELECT failures, last_failed_at FROM failed_attempts WHERE attempt_key = ? FOR UPDATE;
+	`
+
+	var sqlReserveAttempt = `
+		UPDATE failed_attempts SET failures = ?, last_failed_at = ? WHERE attempt_key = ? RETURNING failures, last_failed_at;
+	`
+
+	err = store.db.RunInTx(context.Background(), func(tx db.Query) error {
+		if err := tx.Exec(context.Background(), sqlEnsureAttempt, key, since); err != nil {
+			return err
+		}
+
+		attempt = Attempt{}
+		if err := tx.Raw(context.Background(), &attempt, sqlLockAttempt, key); err != nil {
+			return err
+		}
+
+		if !attempt.LastFailedAt.After(since) {
+			attempt = Attempt{}
+		}
+
+		if allowed = allow(attempt); !allowed {
+			return nil
+		}
+
+		return tx.Raw(context.Background(), &attempt, sqlReserveAttempt, attempt.Failures+1, now, key)
+	})
+	if err != nil || !allowed {
+		return
+	}
+
+	// clean up the failures which are already forgotten
+	err = store.db.Exec(context.Background(), "DELETE FROM failed_attempts WHERE last_failed_at <= ?;", since)
+	return
+}
+
+func (store *postgresStore) Release(key string) error {
+	return store.db.Exec(context.Background(), "UPDATE failed_attempts SET failures = failures - 1 WHERE attempt_key = ? AND failures > 0;", key)
+}
+
+func (store *postgresStore) Reset(key string) error {
+	return store.db.Exec(context.Background(), "DELETE FROM failed_attempts WHERE attempt_key = ?;", key)
+}
@@ -0,0 +1,125 @@
+package lockout_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/lockout"
+)
+
+var testPolicy = lockout.Policy{
+	FreeAttempts:    2,
+	Delay:           time.Second,
+	MaxFailures:     5,
+	LockoutDuration: 15 * time.Minute,
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Limit failed attempts", t, func() {
+		limiter := lockout.NewLimiter(lockout.NewMemoryStore(), testPolicy)
+		now := time.Unix(1538110800, 0)
+
+		fail := func(n int) (status lockout.Status) {
+			for i := 0; i < n; i++ {
+				var err error
+				status, err = limiter.Fail("john", now)
+				convey.So(err, convey.ShouldBeNil)
+			}
+			return
+		}
+
+		convey.Convey("When failures are still in free attempts", func() {
+			convey.So(fail(2).Allowed(), convey.ShouldBeTrue)
+
+			status, err := limiter.Check("john", now)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("When failures are above free attempts, the delay is doubled", func() {
+			status := fail(3)
+			convey.So(status, convey.ShouldResemble, lockout.Status{RetryAfter: time.Second})
+
+			status = fail(1)
+			convey.So(status, convey.ShouldResemble, lockout.Status{RetryAfter: 2 * time.Second})
+
+			status, err := limiter.Check("john", now.Add(time.Second))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status, convey.ShouldResemble, lockout.Status{RetryAfter: time.Second})
+
+			status, err = limiter.Check("john", now.Add(2*time.Second))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("When failures reach the maximum, the key is locked", func() {
+			status := fail(5)
+			convey.So(status, convey.ShouldResemble, lockout.Status{Locked: true, RetryAfter: 15 * time.Minute})
+
+			// other key is not affected
+			status, err := limiter.Check("jane", now)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+
+			// failures are forgotten after the lockout ends
+			status, err = limiter.Check("john", now.Add(15*time.Minute))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+
+			status, err = limiter.Fail("john", now.Add(15*time.Minute))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("When attempts are reserved at the same time, only allowed attempts pass", func() {
+			var wg sync.WaitGroup
+			var mutex sync.Mutex
+			allowed := 0
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					status, err := limiter.Reserve("john", now)
+					if err == nil && status.Allowed() {
+						mutex.Lock()
+						allowed++
+						mutex.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			// free attempts and the one which is checked after them, the rejected attempts are not counted
+			convey.So(allowed, convey.ShouldEqual, 3)
+
+			status, err := limiter.Check("john", now)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status, convey.ShouldResemble, lockout.Status{RetryAfter: time.Second})
+		})
+
+		convey.Convey("When the reserved attempt is correct, it is released", func() {
+			fail(2)
+			status, err := limiter.Reserve("john", now)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+			convey.So(limiter.Release("john"), convey.ShouldBeNil)
+
+			status, err = limiter.Check("john", now)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("When the attempt succeeds, the failures are reset", func() {
+			fail(4)
+			convey.So(limiter.Reset("john"), convey.ShouldBeNil)
+
+			status, err := limiter.Check("john", now)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(status.Allowed(), convey.ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer is an abstraction of email delivery.
+// Use SMTP mailer in production, and file mailer to read the email locally without real mail server.
+type Mailer interface {
+	Send(message *Message) error
+}
+
+// headerSanitizer removes new line from header value, so it cannot add another header
+var headerSanitizer = strings.NewReplacer("\r", "", "\n", "")
+
+// Bytes returns the message in RFC 5322 format which is sent from the from address
+func (message *Message) Bytes(from string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", headerSanitizer.Replace(from))
+	fmt.Fprintf(&buf, "To: %s\r\n", headerSanitizer.Replace(message.To))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerSanitizer.Replace(message.Subject)))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.Replace(message.Body, "\n", "\r\n", -1))
+	return buf.Bytes()
+}
@@ -0,0 +1,59 @@
+package mail
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var logger = log.With().Str("pkg", "mail").Logger()
+
+// File writes each email as .eml file in a directory, so it can be opened using email client
+type File struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates Mailer which writes the email into dir. The dir is created when it doesn't exist.
+func NewFileMailer(dir, from string) (mailer Mailer, err error) {
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+
+	mailer = &File{
+		dir:  dir,
+		from: from,
+	}
+	return
+}
+
+// Send writes the message into new file named by the current time
+func (mailer *File) Send(message *Message) error {
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	return ioutil.WriteFile(filepath.Join(mailer.dir, name), message.Bytes(mailer.from), 0600)
+}
+
+// Log writes the email in application log. The body is not written, since it contains secret such as reset token
+// and anyone who can read the log could use it. Use File mailer to read the whole email in development.
+type Log struct {
+}
+
+// NewLogMailer creates Mailer which only writes the email in log
+func NewLogMailer() (mailer Mailer) {
+	mailer = &Log{}
+	return
+}
+
+// Send writes the recipient and subject of the message in log, the body is redacted
+func (mailer *Log) Send(message *Message) error {
+	logger.Info().
+		Str("to", message.To).
+		Str("subject", message.Subject).
+		Int("body_length", len(message.Body)).
+		Msg("email is not sent, only logged without the body")
+	return nil
+}
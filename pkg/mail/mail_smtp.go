@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"net"
+	"net/smtp"
+)
+
+// SMTP sends the email using SMTP server
+type SMTP struct {
+	address  string
+	from     string
+	username string
+	password string
+}
+
+// NewSMTPMailer creates Mailer which sends the email through SMTP server in address (host:port).
+// When username is empty, the email is sent without authentication.
+func NewSMTPMailer(address, from, username, password string) (mailer Mailer) {
+	mailer = &SMTP{
+		address:  address,
+		from:     from,
+		username: username,
+		password: password,
+	}
+	return
+}
+
+// Send sends the message using SMTP, STARTTLS is used when the server supports it
+func (mailer *SMTP) Send(message *Message) error {
+	var auth smtp.Auth
+	if mailer.username != "" {
+		host, _, err := net.SplitHostPort(mailer.address)
+		if err != nil {
+			return err
+		}
+
+		auth = smtp.PlainAuth("", mailer.username, mailer.password, host)
+	}
+
+	return smtp.SendMail(mailer.address, auth, mailer.from, []string{message.To}, message.Bytes(mailer.from))
+}
@@ -0,0 +1,60 @@
+package mail_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/mail"
+)
+
+func TestFile_Send(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Write email into directory", t, func() {
+		dir, err := ioutil.TempDir("", "mail")
+		convey.So(err, convey.ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		mailer, err := mail.NewFileMailer(dir, "no-reply@example.com")
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("When message is valid", func() {
+			err := mailer.Send(&mail.Message{
+				To:      "john@example.com",
+				Subject: "Reset password",
+				Body:    "line 1\nline 2",
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(files), convey.ShouldEqual, 1)
+
+			content, err := ioutil.ReadFile(files[0])
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(content), convey.ShouldStartWith, "From: no-reply@example.com\r\nTo: john@example.com\r\nSubject: Reset password\r\n")
+			convey.So(string(content), convey.ShouldEndWith, "\r\n\r\nline 1\r\nline 2")
+		})
+
+		convey.Convey("When subject contains new line", func() {
+			err := mailer.Send(&mail.Message{
+				To:      "john@example.com",
+				Subject: "Hello\r\nBcc: attacker@example.com",
+				Body:    "body",
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(files), convey.ShouldEqual, 1)
+
+			content, err := ioutil.ReadFile(files[0])
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(strings.Contains(string(content), "\r\nBcc:"), convey.ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BreachedList checks whether the password is found in a data breach
+type BreachedList interface {
+	Contains(password string) (bool, error)
+}
+
+// NewBreachedList opens the list of uppercase SHA-1 hash of breached password, in the format of Have I Been Pwned Pwned Passwords.
+// The path can be:
+//   - a directory which contains a file for each 5 characters hash prefix (for example 21BD1.txt),
+//     each line is SUFFIX:COUNT like the response of the k-anonymity range api
+//   - a single file where each line is HASH:COUNT, sorted by hash
+//
+// The list is not loaded into memory, only the needed part is read on each check, so the full dump can be used.
+func NewBreachedList(path string) (list BreachedList, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+
+	if info.IsDir() {
+		return &breachedDirectory{dir: path}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+
+	return &breachedFile{file: file, size: info.Size()}, nil
+}
+
+// sha1Hex returns the uppercase hex of SHA-1, as written in the list
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+type breachedDirectory struct {
+	dir string
+}
+
+func (list *breachedDirectory) Contains(password string) (bool, error) {
+	hash := sha1Hex(password)
+	prefix, suffix := hash[:5], hash[5:]
+
+	file, err := os.Open(filepath.Join(list.dir, prefix+".txt"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineHash := strings.SplitN(strings.TrimSpace(scanner.Text()), ":", 2)[0]
+		if strings.EqualFold(lineHash, suffix) {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
+
+// maxBreachedLine is more than enough for 40 characters hash, colon and the count
+const maxBreachedLine = 256
+
+type breachedFile struct {
+	file *os.File
+	size int64
+}
+
+// Contains does binary search on the byte offset, each step reads the first line which starts at or after the middle.
+func (list *breachedFile) Contains(password string) (bool, error) {
+	target := sha1Hex(password)
+
+	low, high := int64(0), list.size
+	for low < high {
+		middle := low + (high-low)/2
+		lineHash, next, err := list.lineFrom(middle)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case lineHash == "":
+			// no line starts after the middle
+			high = middle
+		case lineHash == target:
+			return true, nil
+		case lineHash < target:
+			low = next
+		default:
+			high = middle
+		}
+	}
+
+	return false, nil
+}
+
+// lineFrom returns the hash of the first line which starts at or after offset, and the offset of the next line.
+func (list *breachedFile) lineFrom(offset int64) (hash string, next int64, err error) {
+	// start one byte earlier, so the line which starts exactly at offset is found after the previous new line
+	start := offset
+	if start > 0 {
+		start--
+	}
+
+	buf := make([]byte, 2*maxBreachedLine)
+	n, err := list.file.ReadAt(buf, start)
+	if err != nil && err != io.EOF {
+		return "", 0, err
+	}
+
+	// when the buffer is not full, the end of file is reached
+	endOfFile := n < len(buf)
+	buf = buf[:n]
+
+	lineStart := 0
+	if offset > 0 {
+		newLine := bytes.IndexByte(buf, '\n')
+		if newLine < 0 {
+			if endOfFile {
+				return "", 0, nil
+			}
+			return "", 0, fmt.Errorf("breached list line is too long")
+		}
+		lineStart = newLine + 1
+	}
+
+	line := buf[lineStart:]
+	lineEnd := bytes.IndexByte(line, '\n')
+	if lineEnd >= 0 {
+		line = line[:lineEnd]
+	} else if !endOfFile {
+		return "", 0, fmt.Errorf("breached list line is too long")
+	}
+
+	next = start + int64(lineStart+len(line)+1)
+	hash = strings.ToUpper(strings.SplitN(strings.TrimSpace(string(line)), ":", 2)[0])
+	return hash, next, nil
+}
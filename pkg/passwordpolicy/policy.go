@@ -0,0 +1,138 @@
+// Package passwordpolicy checks whether a password is strong enough before it is saved.
+package passwordpolicy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// rule name of Violation
+const (
+	RuleMinLength        = "min_length"
+	RuleMaxLength        = "max_length"
+	RuleCharacterClasses = "character_classes"
+	RuleSimilarUsername  = "similar_to_username"
+	RuleBreached         = "breached"
+)
+
+// Policy is the rules of password, zero value of a rule means the rule is not checked
+type Policy struct {
+	MinLength           int  // in characters, not bytes
+	MaxLength           int  // in characters, long password is slow to hash
+	MinCharacterClasses int  // number of classes from lowercase, uppercase, digit and symbol
+	NotSimilarUsername  bool // password cannot contain the username, or be contained in it
+	Breached            BreachedList
+}
+
+// DefaultPolicy follows NIST SP 800-63B: length is more important than composition, and breached password is rejected.
+// Breached list is empty, set it using NewBreachedList.
+var DefaultPolicy = Policy{
+	MinLength:           8,
+	MaxLength:           128,
+	MinCharacterClasses: 2,
+	NotSimilarUsername:  true,
+}
+
+// Violation is a rule which is not satisfied
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Validate checks the password of the user with this username against all rules, and returns every rule which fails.
+// Error is only returned when the breached list cannot be read.
+func (policy Policy) Validate(password, username string) (violations []Violation, err error) {
+	length := utf8.RuneCountInString(password)
+	if policy.MinLength > 0 && length < policy.MinLength {
+		violations = append(violations, Violation{
+			Rule:    RuleMinLength,
+			Message: fmt.Sprintf("password must be at least %d characters", policy.MinLength),
+		})
+	}
+
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		violations = append(violations, Violation{
+			Rule:    RuleMaxLength,
+			Message: fmt.Sprintf("password must be at most %d characters", policy.MaxLength),
+		})
+	}
+
+	if policy.MinCharacterClasses > 0 && characterClasses(password) < policy.MinCharacterClasses {
+		violations = append(violations, Violation{
+			Rule:    RuleCharacterClasses,
+			Message: fmt.Sprintf("password must contain at least %d of lowercase, uppercase, digit and symbol", policy.MinCharacterClasses),
+		})
+	}
+
+	if policy.NotSimilarUsername && similar(password, username) {
+		violations = append(violations, Violation{
+			Rule:    RuleSimilarUsername,
+			Message: "password is too similar to the username",
+		})
+	}
+
+	if policy.Breached != nil && password != "" {
+		breached, err := policy.Breached.Contains(password)
+		if err != nil {
+			return nil, err
+		}
+
+		if breached {
+			violations = append(violations, Violation{
+				Rule:    RuleBreached,
+				Message: "password is found in a data breach, please choose another one",
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+
+	return lower + upper + digit + symbol
+}
+
+// similar returns true when the password contains the username, its reverse, or is part of the username.
+// Case and non letter or digit characters are ignored, so "John.Doe1" is similar to username "johndoe".
+func similar(password, username string) bool {
+	password, username = normalize(password), normalize(username)
+	if password == "" || len(username) < 3 {
+		return false
+	}
+
+	return strings.Contains(password, username) || strings.Contains(password, reverse(username)) ||
+		strings.Contains(username, password)
+}
+
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
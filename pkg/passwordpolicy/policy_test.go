@@ -0,0 +1,128 @@
+package passwordpolicy_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/passwordpolicy"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func rules(violations []passwordpolicy.Violation) []string {
+	names := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		names = append(names, violation.Rule)
+	}
+	return names
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Validate password using default policy", t, func() {
+		policy := passwordpolicy.DefaultPolicy
+
+		convey.Convey("When password is good", func() {
+			violations, err := policy.Validate("correct horse battery 9", "john")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(violations, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("When password is too short, every failed rule is returned", func() {
+			violations, err := policy.Validate("a", "john")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(rules(violations), convey.ShouldResemble, []string{
+				passwordpolicy.RuleMinLength,
+				passwordpolicy.RuleCharacterClasses,
+			})
+		})
+
+		convey.Convey("When password contains the username", func() {
+			violations, err := policy.Validate("John.Doe-2018", "johndoe")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(rules(violations), convey.ShouldResemble, []string{passwordpolicy.RuleSimilarUsername})
+
+			violations, err = policy.Validate("eodnhoj!2018", "johndoe")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(rules(violations), convey.ShouldResemble, []string{passwordpolicy.RuleSimilarUsername})
+		})
+
+		convey.Convey("When password is too long", func() {
+			violations, err := policy.Validate(strings.Repeat("aB", 65), "john")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(rules(violations), convey.ShouldResemble, []string{passwordpolicy.RuleMaxLength})
+		})
+	})
+}
+
+func TestNewBreachedList(t *testing.T) {
+	t.Parallel()
+
+	breached := []string{"password", "123456", "qwerty", "P@ssw0rd2018", "letmein", "iloveyou", "monkey"}
+
+	convey.Convey("Check breached password offline", t, func() {
+		convey.Convey("When the list is a single file sorted by hash", func() {
+			hashes := make([]string, 0, len(breached))
+			for i, password := range breached {
+				hashes = append(hashes, fmt.Sprintf("%s:%d", sha1Hex(password), i+1))
+			}
+			sort.Strings(hashes)
+
+			file, err := ioutil.TempFile("", "pwned-passwords-*.txt")
+			convey.So(err, convey.ShouldBeNil)
+			file.WriteString(strings.Join(hashes, "\r\n") + "\r\n")
+			file.Close()
+			defer os.Remove(file.Name())
+
+			list, err := passwordpolicy.NewBreachedList(file.Name())
+			convey.So(err, convey.ShouldBeNil)
+
+			for _, password := range breached {
+				found, err := list.Contains(password)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(found, convey.ShouldBeTrue)
+			}
+
+			for _, password := range []string{"", "correct horse battery 9", "zzzzzz", "P@ssw0rd2019"} {
+				found, err := list.Contains(password)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(found, convey.ShouldBeFalse)
+			}
+		})
+
+		convey.Convey("When the list is a directory of hash prefix files", func() {
+			dir, err := ioutil.TempDir("", "pwned-passwords")
+			convey.So(err, convey.ShouldBeNil)
+			defer os.RemoveAll(dir)
+
+			hash := sha1Hex("P@ssw0rd2018")
+			content := "0018A45C4D1DEF81644B54AB7F969B88D65:1\n" + hash[5:] + ":3\n"
+			convey.So(ioutil.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(content), 0644), convey.ShouldBeNil)
+
+			policy := passwordpolicy.DefaultPolicy
+			policy.Breached, err = passwordpolicy.NewBreachedList(dir)
+			convey.So(err, convey.ShouldBeNil)
+
+			violations, err := policy.Validate("P@ssw0rd2018", "john")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(rules(violations), convey.ShouldResemble, []string{passwordpolicy.RuleBreached})
+
+			// prefix file doesn't exist
+			violations, err = policy.Validate("correct horse battery 9", "john")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(violations, convey.ShouldBeEmpty)
+		})
+	})
+}
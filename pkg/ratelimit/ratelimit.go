@@ -0,0 +1,65 @@
+// Package ratelimit limits the number of requests in a time window using sliding window counter.
+// The hits of the current and previous fixed window are kept, and the previous window is weighted
+// by how much of it still overlaps the sliding window. It is close to a real sliding window
+// without keeping the time of every request.
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Store keeps the hits of each fixed window. Use memory store for a single server,
+// and postgres store when several servers must share the same count.
+type Store interface {
+	// Increment adds one hit into the window of the key which starts at windowStart,
+	// and returns the hits in this window and in the previous window.
+	Increment(key string, windowStart time.Time, window time.Duration) (current, previous int, err error)
+}
+
+// Limiter allows Limit requests in Window for each key
+type Limiter struct {
+	store  Store
+	limit  int
+	window time.Duration
+}
+
+// Result is the state of the key after the request is counted
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int           // requests which are still allowed in the current window
+	Reset     time.Duration // time until the current window ends
+}
+
+// NewLimiter creates limiter, limiters can share the same store as long as the keys are different.
+func NewLimiter(store Store, limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		store:  store,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Allow counts the request of the key at now, and returns whether it is still in the limit.
+// Rejected request is counted too, so client which keeps sending requests stays limited.
+func (limiter *Limiter) Allow(key string, now time.Time) (result Result, err error) {
+	windowStart := now.Truncate(limiter.window)
+	current, previous, err := limiter.store.Increment(key, windowStart, limiter.window)
+	if err != nil {
+		return
+	}
+
+	elapsed := now.Sub(windowStart)
+	weight := 1 - float64(elapsed)/float64(limiter.window)
+	estimated := float64(previous)*weight + float64(current)
+
+	result = Result{
+		Allowed:   estimated <= float64(limiter.limit),
+		Limit:     limiter.limit,
+		Remaining: int(math.Max(0, math.Floor(float64(limiter.limit)-estimated))),
+		Reset:     limiter.window - elapsed,
+	}
+
+	return
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// maxMemoryKeys is the number of keys before we try to remove the windows which are too old to be counted
+const maxMemoryKeys = 10000
+
+type memoryWindow struct {
+	start    time.Time
+	length   time.Duration // limiters with different window can share the store
+	current  int
+	previous int
+}
+
+type memoryStore struct {
+	mutex   sync.Mutex
+	windows map[string]*memoryWindow
+	sweptAt time.Time // window start of the last removal
+}
+
+// NewMemoryStore creates store which keeps the hits in memory, so it only works for a single server.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		windows: map[string]*memoryWindow{},
+	}
+}
+
+func (store *memoryStore) Increment(key string, windowStart time.Time, window time.Duration) (current, previous int, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	// the key which is not hit in the last two of its windows doesn't affect the count anymore.
+	// No key becomes too old until the next window starts, so removing them once per window is enough.
+	if len(store.windows) >= maxMemoryKeys && windowStart.After(store.sweptAt) {
+		for k, v := range store.windows {
+			if !v.start.Add(2 * v.length).After(windowStart) {
+				delete(store.windows, k)
+			}
+		}
+
+		store.sweptAt = windowStart
+	}
+
+	w, ok := store.windows[key]
+	switch {
+	case !ok || w.start.Before(windowStart.Add(-window)):
+		w = &memoryWindow{start: windowStart, length: window}
+		store.windows[key] = w
+	case w.start.Before(windowStart):
+		// previous window is the last current window
+		w.start, w.previous, w.current = windowStart, w.current, 0
+	}
+
+	w.current++
+	return w.current, w.previous, nil
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
+)
+
+type postgresStore struct {
+	db db.Query
+}
+
+// NewPostgresStore creates store using rate_limits table, so all servers share the same count.
+// Queries don't use the request context, otherwise client can disconnect early so the request is not counted.
+func NewPostgresStore(db db.Query) Store {
+	return &postgresStore{
+		db: db,
+	}
+}
+
+func (store *postgresStore) Increment(key string, windowStart time.Time, window time.Duration) (current, previous int, err error) {
+	var sqlIncrement = `
+		WITH hit AS (
+			INSERT INTO rate_limits (limit_key, window_start, hits, expired_at) VALUES (?, ?, 1, ?)
+			ON CONFLICT (limit_key, window_start) DO UPDATE SET hits = rate_limits.hits + 1
+			RETURNING hits
+		)
+		SELECT
+			(SELECT hits FROM hit) AS current,
+			COALESCE((SELECT hits FROM rate_limits WHERE limit_key = ? AND window_start = ?), 0) AS previous;
+	`
+
+	var result struct {
+		Current  int
+		Previous int
+	}
+
+	previousStart := windowStart.Add(-window)
+	// the window is still needed as the previous window of the next one
+	expiredAt := windowStart.Add(2 * window)
+	if err = store.db.Raw(context.Background(), &result, sqlIncrement, key, windowStart, expiredAt, key, previousStart); err != nil {
+		return
+	}
+
+	// first hit of the window, clean up the windows which are not used anymore
+	if result.Current == 1 {
+		if err = store.db.Exec(context.Background(), "DELETE FROM rate_limits WHERE expired_at < ?;", windowStart); err != nil {
+			return
+		}
+	}
+
+	return result.Current, result.Previous, nil
+}
@@ -0,0 +1,68 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/ratelimit"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Limit requests using sliding window", t, func() {
+		limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), 10, time.Minute)
+		windowStart := time.Unix(1538197200, 0)
+
+		hit := func(key string, now time.Time, n int) (result ratelimit.Result) {
+			for i := 0; i < n; i++ {
+				var err error
+				result, err = limiter.Allow(key, now)
+				convey.So(err, convey.ShouldBeNil)
+			}
+			return
+		}
+
+		convey.Convey("When requests are in the limit", func() {
+			result := hit("a", windowStart.Add(15*time.Second), 10)
+			convey.So(result, convey.ShouldResemble, ratelimit.Result{
+				Allowed:   true,
+				Limit:     10,
+				Remaining: 0,
+				Reset:     45 * time.Second,
+			})
+		})
+
+		convey.Convey("When requests are above the limit", func() {
+			result := hit("a", windowStart, 11)
+			convey.So(result.Allowed, convey.ShouldBeFalse)
+			convey.So(result.Remaining, convey.ShouldEqual, 0)
+
+			// other key is not affected
+			result = hit("b", windowStart, 1)
+			convey.So(result.Allowed, convey.ShouldBeTrue)
+			convey.So(result.Remaining, convey.ShouldEqual, 9)
+		})
+
+		convey.Convey("When the previous window is still overlapping", func() {
+			hit("a", windowStart, 10)
+
+			// a quarter of next window has passed, so 75% of 10 requests is still counted
+			result := hit("a", windowStart.Add(75*time.Second), 1)
+			convey.So(result.Allowed, convey.ShouldBeTrue)
+			convey.So(result.Remaining, convey.ShouldEqual, 1)
+
+			result = hit("a", windowStart.Add(75*time.Second), 2)
+			convey.So(result.Allowed, convey.ShouldBeFalse)
+		})
+
+		convey.Convey("When the previous window is too old", func() {
+			hit("a", windowStart, 20)
+
+			result := hit("a", windowStart.Add(2*time.Minute), 1)
+			convey.So(result.Allowed, convey.ShouldBeTrue)
+			convey.So(result.Remaining, convey.ShouldEqual, 9)
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package totp_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/totp"
+)
+
+// base32 of "12345678901234567890", the SHA1 secret in RFC 6238 appendix B
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Generate code using test vectors from RFC 6238", t, func() {
+		// RFC uses 8 digits, 6 digits code is the last 6 digits
+		vectors := map[int64]string{
+			59:         "94287082",
+			1111111109: "07081804",
+			1111111111: "14050471",
+			1234567890: "89005924",
+			2000000000: "69279037",
+		}
+
+		for unix, expected := range vectors {
+			code, err := totp.Code(rfcSecret, time.Unix(unix, 0))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(code, convey.ShouldEqual, expected[2:])
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Validate code", t, func() {
+		now := time.Unix(1111111109, 0)
+		code, err := totp.Code(rfcSecret, now)
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("When code is from current time step", func() {
+			counter, ok := totp.Validate(rfcSecret, code, now)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(counter, convey.ShouldEqual, 1111111109/30)
+		})
+
+		convey.Convey("When code is from previous time step", func() {
+			counter, ok := totp.Validate(rfcSecret, code, now.Add(totp.Period))
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(counter, convey.ShouldEqual, 1111111109/30)
+		})
+
+		convey.Convey("When code is too old", func() {
+			_, ok := totp.Validate(rfcSecret, code, now.Add(2*totp.Period))
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+
+		convey.Convey("When code is wrong", func() {
+			_, ok := totp.Validate(rfcSecret, "000000", now)
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+	})
+}
+
+func TestURI(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Build otpauth uri", t, func() {
+		secret, err := totp.GenerateSecret()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(secret), convey.ShouldEqual, 32)
+
+		uri := totp.URI("My App", "john", secret)
+		convey.So(uri, convey.ShouldStartWith, "otpauth://totp/My%20App:john?")
+		convey.So(strings.Contains(uri, "secret="+secret), convey.ShouldBeTrue)
+		convey.So(strings.Contains(uri, "issuer=My+App"), convey.ShouldBeTrue)
+	})
+}
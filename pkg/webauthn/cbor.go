@@ -0,0 +1,135 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// maxCBORDepth limits nested array and map, authenticator data never needs more than a few level
+const maxCBORDepth = 16
+
+// decodeCBOR decodes one CBOR (RFC 7049) data item and returns the rest of the input.
+// Only the subset used by WebAuthn is supported: integer, byte string, text string, array, map, and simple value.
+// Integer is returned as int64, map as map[interface{}]interface{} with int64 or string keys.
+func decodeCBOR(data []byte) (value interface{}, rest []byte, err error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (value interface{}, rest []byte, err error) {
+	if depth > maxCBORDepth {
+		return nil, nil, fmt.Errorf("cbor: too deep")
+	}
+
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("cbor: unexpected end of data")
+	}
+
+	majorType := data[0] >> 5
+	info := data[0] & 0x1f
+
+	// simple value and float
+	if majorType == 7 {
+		switch info {
+		case 20:
+			return false, data[1:], nil
+		case 21:
+			return true, data[1:], nil
+		case 22, 23:
+			return nil, data[1:], nil
+		default:
+			return nil, nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+		}
+	}
+
+	argument, data, err := decodeCBORArgument(info, data[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch majorType {
+	case 0:
+		if argument > 1<<63-1 {
+			return nil, nil, fmt.Errorf("cbor: integer overflow")
+		}
+		return int64(argument), data, nil
+	case 1:
+		if argument > 1<<63-1 {
+			return nil, nil, fmt.Errorf("cbor: integer overflow")
+		}
+		return -1 - int64(argument), data, nil
+	case 2, 3:
+		if uint64(len(data)) < argument {
+			return nil, nil, fmt.Errorf("cbor: unexpected end of data")
+		}
+
+		if majorType == 2 {
+			return append([]byte(nil), data[:argument]...), data[argument:], nil
+		}
+		return string(data[:argument]), data[argument:], nil
+	case 4:
+		// each item needs at least one byte, so it cannot be longer than data
+		if uint64(len(data)) < argument {
+			return nil, nil, fmt.Errorf("cbor: unexpected end of data")
+		}
+
+		array := make([]interface{}, 0, argument)
+		for i := uint64(0); i < argument; i++ {
+			var item interface{}
+			item, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			array = append(array, item)
+		}
+		return array, data, nil
+	case 5:
+		if uint64(len(data))/2 < argument {
+			return nil, nil, fmt.Errorf("cbor: unexpected end of data")
+		}
+
+		m := make(map[interface{}]interface{}, argument)
+		for i := uint64(0); i < argument; i++ {
+			var key, item interface{}
+			key, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("cbor: unsupported map key type %T", key)
+			}
+
+			item, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[key] = item
+		}
+		return m, data, nil
+	default:
+		return nil, nil, fmt.Errorf("cbor: unsupported major type %d", majorType)
+	}
+}
+
+// decodeCBORArgument reads the length or value which follows the initial byte
+func decodeCBORArgument(info byte, data []byte) (argument uint64, rest []byte, err error) {
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24 && len(data) >= 1:
+		return uint64(data[0]), data[1:], nil
+	case info == 25 && len(data) >= 2:
+		return uint64(binary.BigEndian.Uint16(data)), data[2:], nil
+	case info == 26 && len(data) >= 4:
+		return uint64(binary.BigEndian.Uint32(data)), data[4:], nil
+	case info == 27 && len(data) >= 8:
+		return binary.BigEndian.Uint64(data), data[8:], nil
+	case info >= 24 && info <= 27:
+		return 0, nil, fmt.Errorf("cbor: unexpected end of data")
+	default:
+		// indefinite length is not allowed in CTAP2 canonical encoding
+		return 0, nil, fmt.Errorf("cbor: unsupported additional information %d", info)
+	}
+}
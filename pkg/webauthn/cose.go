@@ -0,0 +1,129 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifier (RFC 8152), the value of pubKeyCredParams in credential creation options
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms is the list of algorithm accepted from authenticator, the preferred first
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE key parameters
+const (
+	coseKeyType   int64 = 1
+	coseAlgorithm int64 = 3
+	coseCurve     int64 = -1 // EC2 and OKP
+	coseX         int64 = -2 // EC2 and OKP
+	coseY         int64 = -3 // EC2
+	coseN         int64 = -1 // RSA
+	coseE         int64 = -2 // RSA
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+)
+
+// PublicKey is credential public key decoded from COSE_Key format
+type PublicKey struct {
+	Algorithm int64
+	Key       crypto.PublicKey
+}
+
+// ParsePublicKey decodes COSE_Key, only ES256 (P-256), EdDSA (Ed25519) and RS256 are supported.
+func ParsePublicKey(coseKey []byte) (publicKey *PublicKey, err error) {
+	decoded, rest, err := decodeCBOR(coseKey)
+	if err != nil {
+		return
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("unexpected data after public key")
+	}
+
+	return publicKeyFromCOSE(decoded)
+}
+
+func publicKeyFromCOSE(decoded interface{}) (publicKey *PublicKey, err error) {
+	params, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("public key is not a map")
+	}
+
+	keyType, _ := params[coseKeyType].(int64)
+	alg, _ := params[coseAlgorithm].(int64)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && alg == AlgES256:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+		y, _ := params[coseY].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid ES256 public key")
+		}
+
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("ES256 public key is not on the curve")
+		}
+
+		return &PublicKey{Algorithm: alg, Key: key}, nil
+	case keyType == coseKeyTypeOKP && alg == AlgEdDSA:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid EdDSA public key")
+		}
+
+		return &PublicKey{Algorithm: alg, Key: ed25519.PublicKey(x)}, nil
+	case keyType == coseKeyTypeRSA && alg == AlgRS256:
+		n, _ := params[coseN].([]byte)
+		e, _ := params[coseE].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RS256 public key")
+		}
+
+		return &PublicKey{Algorithm: alg, Key: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %d with algorithm %d", keyType, alg)
+	}
+}
+
+// Verify checks the signature of the message using this key
+func (publicKey *PublicKey) Verify(message, signature []byte) error {
+	switch key := publicKey.Key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return fmt.Errorf("signature is invalid")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, message, signature) {
+			return fmt.Errorf("signature is invalid")
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("signature is invalid")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey.Key)
+	}
+
+	return nil
+}
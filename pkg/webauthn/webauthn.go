@@ -0,0 +1,366 @@
+// Package webauthn implements the relying party part of Web Authentication (W3C WebAuthn Level 2),
+// which is used for passwordless login using passkey or security key.
+//
+// Only "none" attestation is supported: the attestation statement is not verified,
+// so the authenticator model is not checked, but the credential is still bound to the user and this server.
+// Binary values in the options are encoded using base64url without padding.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Timeout is the time given to the user to finish the ceremony in the browser
+const Timeout = 5 * time.Minute
+
+// flags in authenticator data
+const (
+	FlagUserPresent            byte = 0x01
+	FlagUserVerified           byte = 0x04
+	FlagAttestedCredentialData byte = 0x40
+	FlagExtensionData          byte = 0x80
+)
+
+// Config is the relying party configuration
+type Config struct {
+	RPID   string // domain of the website, for example example.com
+	RPName string // human readable name which is shown by the browser
+	Origin string // origin of the page which calls the WebAuthn API, for example https://example.com
+}
+
+// Credential is the public key credential created by the authenticator
+type Credential struct {
+	ID        []byte
+	PublicKey []byte // in COSE_Key format
+	SignCount uint32
+}
+
+// CollectedClientData is the client data which is signed by the authenticator
+type CollectedClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+// AuthenticatorData is the data created by the authenticator, see WebAuthn section 6.1
+type AuthenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+
+	// only when FlagAttestedCredentialData is set
+	AAGUID              []byte
+	CredentialID        []byte
+	CredentialPublicKey []byte
+}
+
+// RelyingParty is rp in PublicKeyCredentialCreationOptions
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity is user in PublicKeyCredentialCreationOptions
+type UserEntity struct {
+	ID          string `json:"id"` // user handle
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameter is one of pubKeyCredParams in PublicKeyCredentialCreationOptions
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// CredentialDescriptor is one of excludeCredentials or allowCredentials
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// AuthenticatorSelection is authenticatorSelection in PublicKeyCredentialCreationOptions
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions is PublicKeyCredentialCreationOptions, the parameter of navigator.credentials.create()
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingParty           `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions is PublicKeyCredentialRequestOptions, the parameter of navigator.credentials.get()
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// GenerateChallenge returns random 32 bytes challenge in base64url
+func GenerateChallenge() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreationOptions returns the options to register new credential for the user.
+// User verification is required, since the credential replaces the password.
+// Credentials which are already registered are excluded, so the same authenticator is not registered twice.
+func (config Config) CreationOptions(challenge string, userHandle []byte, name, displayName string, exclude [][]byte) CreationOptions {
+	params := make([]CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, CredentialParameter{Type: "public-key", Alg: alg})
+	}
+
+	return CreationOptions{
+		Challenge: challenge,
+		RP: RelyingParty{
+			ID:   config.RPID,
+			Name: config.RPName,
+		},
+		User: UserEntity{
+			ID:          base64.RawURLEncoding.EncodeToString(userHandle),
+			Name:        name,
+			DisplayName: displayName,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            int64(Timeout / time.Millisecond),
+		ExcludeCredentials: credentialDescriptors(exclude),
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "required",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns the options to login. When allow is empty, browser asks user to choose one of
+// discoverable credentials (passkey) for this rp id.
+func (config Config) RequestOptions(challenge string, allow [][]byte) RequestOptions {
+	return RequestOptions{
+		Challenge:        challenge,
+		Timeout:          int64(Timeout / time.Millisecond),
+		RPID:             config.RPID,
+		AllowCredentials: credentialDescriptors(allow),
+		UserVerification: "required",
+	}
+}
+
+func credentialDescriptors(credentialIDs [][]byte) []CredentialDescriptor {
+	descriptors := make([]CredentialDescriptor, 0, len(credentialIDs))
+	for _, credentialID := range credentialIDs {
+		descriptors = append(descriptors, CredentialDescriptor{
+			Type: "public-key",
+			ID:   base64.RawURLEncoding.EncodeToString(credentialID),
+		})
+	}
+
+	return descriptors
+}
+
+// ParseClientData decodes clientDataJSON. It is used to get the challenge before the ceremony is verified,
+// so the server knows which session the response belongs to.
+func ParseClientData(clientDataJSON []byte) (clientData *CollectedClientData, err error) {
+	clientData = &CollectedClientData{}
+	if err = json.Unmarshal(clientDataJSON, clientData); err != nil {
+		return nil, fmt.Errorf("client data is not valid json: %s", err.Error())
+	}
+
+	return
+}
+
+// VerifyRegistration verifies the response of navigator.credentials.create(), see WebAuthn section 7.1.
+func (config Config) VerifyRegistration(clientDataJSON, attestationObject []byte, challenge string) (credential *Credential, err error) {
+	if err = config.verifyClientData(clientDataJSON, "webauthn.create", challenge); err != nil {
+		return
+	}
+
+	decoded, rest, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("attestation object is not valid: %s", err.Error())
+	}
+
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok || len(rest) != 0 {
+		return nil, fmt.Errorf("attestation object is not valid")
+	}
+
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("attestation object has no authenticator data")
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return
+	}
+
+	if err = config.verifyAuthenticatorData(authData); err != nil {
+		return
+	}
+
+	if authData.Flags&FlagAttestedCredentialData == 0 {
+		return nil, fmt.Errorf("authenticator data has no attested credential data")
+	}
+
+	// make sure the key can be used later in assertion
+	if _, err = ParsePublicKey(authData.CredentialPublicKey); err != nil {
+		return
+	}
+
+	credential = &Credential{
+		ID:        authData.CredentialID,
+		PublicKey: authData.CredentialPublicKey,
+		SignCount: authData.SignCount,
+	}
+
+	return
+}
+
+// VerifyAssertion verifies the response of navigator.credentials.get() using the stored credential,
+// see WebAuthn section 7.2. It returns the new signature counter which must be saved.
+func (config Config) VerifyAssertion(credential *Credential, clientDataJSON, rawAuthData, signature []byte, challenge string) (signCount uint32, err error) {
+	if err = config.verifyClientData(clientDataJSON, "webauthn.get", challenge); err != nil {
+		return
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return
+	}
+
+	if err = config.verifyAuthenticatorData(authData); err != nil {
+		return
+	}
+
+	publicKey, err := ParsePublicKey(credential.PublicKey)
+	if err != nil {
+		return
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash[:]...)
+	if err = publicKey.Verify(signed, signature); err != nil {
+		return
+	}
+
+	// authenticator which doesn't support counter always sends 0,
+	// otherwise the counter must increase or the authenticator may be cloned
+	if (authData.SignCount != 0 || credential.SignCount != 0) && authData.SignCount <= credential.SignCount {
+		return 0, fmt.Errorf("signature counter is not increased, the authenticator may be cloned")
+	}
+
+	return authData.SignCount, nil
+}
+
+func (config Config) verifyClientData(clientDataJSON []byte, ceremony, challenge string) error {
+	clientData, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+
+	if clientData.Type != ceremony {
+		return fmt.Errorf("client data type must be %s", ceremony)
+	}
+
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("challenge does not match")
+	}
+
+	if clientData.Origin != config.Origin {
+		return fmt.Errorf("origin %s is not allowed", clientData.Origin)
+	}
+
+	return nil
+}
+
+func (config Config) verifyAuthenticatorData(authData *AuthenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(config.RPID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return fmt.Errorf("rp id hash does not match")
+	}
+
+	if authData.Flags&FlagUserPresent == 0 {
+		return fmt.Errorf("user is not present")
+	}
+
+	if authData.Flags&FlagUserVerified == 0 {
+		return fmt.Errorf("user is not verified")
+	}
+
+	return nil
+}
+
+// ParseAuthenticatorData decodes authenticator data, see WebAuthn section 6.1.
+func ParseAuthenticatorData(data []byte) (authData *AuthenticatorData, err error) {
+	if len(data) < 37 {
+		return nil, fmt.Errorf("authenticator data is too short")
+	}
+
+	authData = &AuthenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	rest := data[37:]
+	if authData.Flags&FlagAttestedCredentialData != 0 {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("attested credential data is too short")
+		}
+
+		authData.AAGUID = rest[:16]
+		credentialIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+
+		if credentialIDLength > 1023 || len(rest) < credentialIDLength {
+			return nil, fmt.Errorf("credential id is not valid")
+		}
+
+		authData.CredentialID = rest[:credentialIDLength]
+		rest = rest[credentialIDLength:]
+
+		// the key length is not written, so decode it to find where the key ends
+		_, afterKey, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("credential public key is not valid: %s", err.Error())
+		}
+
+		authData.CredentialPublicKey = rest[:len(rest)-len(afterKey)]
+		rest = afterKey
+	}
+
+	if authData.Flags&FlagExtensionData != 0 {
+		if _, rest, err = decodeCBOR(rest); err != nil {
+			return nil, fmt.Errorf("extension data is not valid: %s", err.Error())
+		}
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("unexpected data after authenticator data")
+	}
+
+	return
+}
@@ -0,0 +1,332 @@
+package webauthn_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/webauthn"
+)
+
+var testConfig = webauthn.Config{
+	RPID:   "example.com",
+	RPName: "Example",
+	Origin: "https://example.com",
+}
+
+// cborPair is a map entry, map is written as list of pairs to keep the canonical order
+type cborPair struct {
+	key   interface{}
+	value interface{}
+}
+
+// encodeCBOR is a minimal CBOR encoder for the software authenticator
+func encodeCBOR(value interface{}) []byte {
+	header := func(majorType byte, argument uint64) []byte {
+		switch {
+		case argument < 24:
+			return []byte{majorType<<5 | byte(argument)}
+		case argument < 1<<8:
+			return []byte{majorType<<5 | 24, byte(argument)}
+		case argument < 1<<16:
+			b := []byte{majorType<<5 | 25, 0, 0}
+			binary.BigEndian.PutUint16(b[1:], uint16(argument))
+			return b
+		default:
+			b := []byte{majorType<<5 | 26, 0, 0, 0, 0}
+			binary.BigEndian.PutUint32(b[1:], uint32(argument))
+			return b
+		}
+	}
+
+	switch value := value.(type) {
+	case int:
+		if value < 0 {
+			return header(1, uint64(-1-value))
+		}
+		return header(0, uint64(value))
+	case []byte:
+		return append(header(2, uint64(len(value))), value...)
+	case string:
+		return append(header(3, uint64(len(value))), value...)
+	case []cborPair:
+		out := header(5, uint64(len(value)))
+		for _, pair := range value {
+			out = append(out, encodeCBOR(pair.key)...)
+			out = append(out, encodeCBOR(pair.value)...)
+		}
+		return out
+	default:
+		panic("unsupported type")
+	}
+}
+
+// softwareAuthenticator acts as browser and authenticator, so the ceremonies can be tested offline
+type softwareAuthenticator struct {
+	rpID         string
+	origin       string
+	flags        byte
+	credentialID []byte
+	signer       crypto.Signer
+	signCount    uint32
+}
+
+func newSoftwareAuthenticator(t *testing.T, signer crypto.Signer) *softwareAuthenticator {
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		t.Fatal(err)
+	}
+
+	return &softwareAuthenticator{
+		rpID:         testConfig.RPID,
+		origin:       testConfig.Origin,
+		flags:        webauthn.FlagUserPresent | webauthn.FlagUserVerified,
+		credentialID: credentialID,
+		signer:       signer,
+	}
+}
+
+func (authenticator *softwareAuthenticator) coseKey() []byte {
+	switch publicKey := authenticator.signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		x, y := make([]byte, 32), make([]byte, 32)
+		publicKey.X.FillBytes(x)
+		publicKey.Y.FillBytes(y)
+		return encodeCBOR([]cborPair{{1, 2}, {3, -7}, {-1, 1}, {-2, x}, {-3, y}})
+	case ed25519.PublicKey:
+		return encodeCBOR([]cborPair{{1, 1}, {3, -8}, {-1, 6}, {-2, []byte(publicKey)}})
+	default:
+		panic("unsupported key")
+	}
+}
+
+func (authenticator *softwareAuthenticator) clientData(ceremony, challenge string) []byte {
+	clientDataJSON, _ := json.Marshal(map[string]interface{}{
+		"type":      ceremony,
+		"challenge": challenge,
+		"origin":    authenticator.origin,
+	})
+	return clientDataJSON
+}
+
+func (authenticator *softwareAuthenticator) authData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(authenticator.rpID))
+	authData := append(rpIDHash[:], flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(authData[33:], authenticator.signCount)
+	return authData
+}
+
+// create returns clientDataJSON and attestationObject with none attestation
+func (authenticator *softwareAuthenticator) create(challenge string) (clientDataJSON, attestationObject []byte) {
+	authData := authenticator.authData(authenticator.flags | webauthn.FlagAttestedCredentialData)
+	authData = append(authData, make([]byte, 16)...) // aaguid
+	authData = append(authData, byte(len(authenticator.credentialID)>>8), byte(len(authenticator.credentialID)))
+	authData = append(authData, authenticator.credentialID...)
+	authData = append(authData, authenticator.coseKey()...)
+
+	attestationObject = encodeCBOR([]cborPair{
+		{"fmt", "none"},
+		{"attStmt", []cborPair{}},
+		{"authData", authData},
+	})
+
+	return authenticator.clientData("webauthn.create", challenge), attestationObject
+}
+
+// get returns clientDataJSON, authenticatorData and signature, the signature counter is increased
+func (authenticator *softwareAuthenticator) get(challenge string) (clientDataJSON, authData, signature []byte) {
+	authenticator.signCount++
+	clientDataJSON = authenticator.clientData("webauthn.get", challenge)
+	authData = authenticator.authData(authenticator.flags)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), authData...), clientDataHash[:]...)
+
+	var err error
+	switch signer := authenticator.signer.(type) {
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(signer, signed)
+	default:
+		digest := sha256.Sum256(signed)
+		signature, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers := map[string]crypto.Signer{
+		"ES256": ecdsaKey,
+		"EdDSA": ed25519Key,
+	}
+
+	convey.Convey("Register credential then login using software authenticator", t, func() {
+		for alg, signer := range signers {
+			alg, signer := alg, signer
+			convey.Convey("When the key is "+alg, func() {
+				authenticator := newSoftwareAuthenticator(t, signer)
+
+				challenge, err := webauthn.GenerateChallenge()
+				convey.So(err, convey.ShouldBeNil)
+
+				clientDataJSON, attestationObject := authenticator.create(challenge)
+				credential, err := testConfig.VerifyRegistration(clientDataJSON, attestationObject, challenge)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(credential.ID, convey.ShouldResemble, authenticator.credentialID)
+				convey.So(credential.SignCount, convey.ShouldEqual, 0)
+
+				challenge, err = webauthn.GenerateChallenge()
+				convey.So(err, convey.ShouldBeNil)
+
+				clientDataJSON, authData, signature := authenticator.get(challenge)
+				signCount, err := testConfig.VerifyAssertion(credential, clientDataJSON, authData, signature, challenge)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(signCount, convey.ShouldEqual, 1)
+			})
+		}
+	})
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	convey.Convey("Reject invalid registration", t, func() {
+		authenticator := newSoftwareAuthenticator(t, key)
+
+		convey.Convey("When the challenge is different", func() {
+			clientDataJSON, attestationObject := authenticator.create("other-challenge")
+			_, err := testConfig.VerifyRegistration(clientDataJSON, attestationObject, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "challenge does not match")
+		})
+
+		convey.Convey("When the origin is different", func() {
+			authenticator.origin = "https://evil.example"
+			clientDataJSON, attestationObject := authenticator.create("challenge")
+			_, err := testConfig.VerifyRegistration(clientDataJSON, attestationObject, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "origin https://evil.example is not allowed")
+		})
+
+		convey.Convey("When the rp id is different", func() {
+			authenticator.rpID = "evil.example"
+			clientDataJSON, attestationObject := authenticator.create("challenge")
+			_, err := testConfig.VerifyRegistration(clientDataJSON, attestationObject, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "rp id hash does not match")
+		})
+
+		convey.Convey("When the user is not verified", func() {
+			authenticator.flags = webauthn.FlagUserPresent
+			clientDataJSON, attestationObject := authenticator.create("challenge")
+			_, err := testConfig.VerifyRegistration(clientDataJSON, attestationObject, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "user is not verified")
+		})
+
+		convey.Convey("When the response is from assertion", func() {
+			clientDataJSON, _ := authenticator.create("challenge")
+			_, authData, _ := authenticator.get("challenge")
+			attestationObject := encodeCBOR([]cborPair{{"fmt", "none"}, {"attStmt", []cborPair{}}, {"authData", authData}})
+
+			_, err := testConfig.VerifyRegistration(clientDataJSON, attestationObject, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "authenticator data has no attested credential data")
+		})
+	})
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	convey.Convey("Reject invalid assertion", t, func() {
+		authenticator := newSoftwareAuthenticator(t, key)
+		clientDataJSON, attestationObject := authenticator.create("challenge")
+		credential, err := testConfig.VerifyRegistration(clientDataJSON, attestationObject, "challenge")
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("When the response is from registration", func() {
+			clientDataJSON, _ := authenticator.create("challenge")
+			_, authData, signature := authenticator.get("challenge")
+			_, err := testConfig.VerifyAssertion(credential, clientDataJSON, authData, signature, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "client data type must be webauthn.get")
+		})
+
+		convey.Convey("When it is signed by other key", func() {
+			authenticator.signer = otherKey
+			clientDataJSON, authData, signature := authenticator.get("challenge")
+			_, err := testConfig.VerifyAssertion(credential, clientDataJSON, authData, signature, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "signature is invalid")
+		})
+
+		convey.Convey("When the signature counter goes back", func() {
+			clientDataJSON, authData, signature := authenticator.get("challenge")
+			credential.SignCount = 5
+			_, err := testConfig.VerifyAssertion(credential, clientDataJSON, authData, signature, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "signature counter is not increased, the authenticator may be cloned")
+		})
+
+		convey.Convey("When the authenticator data is changed after signed", func() {
+			clientDataJSON, authData, signature := authenticator.get("challenge")
+			authData[36]++
+			_, err := testConfig.VerifyAssertion(credential, clientDataJSON, authData, signature, "challenge")
+			convey.So(err.Error(), convey.ShouldEqual, "signature is invalid")
+		})
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Parse COSE key", t, func() {
+		convey.Convey("When the algorithm is not supported", func() {
+			_, err := webauthn.ParsePublicKey(encodeCBOR([]cborPair{{1, 2}, {3, -35}, {-1, 2}}))
+			convey.So(err.Error(), convey.ShouldEqual, "unsupported public key type 2 with algorithm -35")
+		})
+
+		convey.Convey("When the point is not on the curve", func() {
+			x, y := make([]byte, 32), make([]byte, 32)
+			y[31] = 1
+			_, err := webauthn.ParsePublicKey(encodeCBOR([]cborPair{{1, 2}, {3, -7}, {-1, 1}, {-2, x}, {-3, y}}))
+			convey.So(err.Error(), convey.ShouldEqual, "ES256 public key is not on the curve")
+		})
+
+		convey.Convey("When the data is truncated", func() {
+			_, err := webauthn.ParsePublicKey([]byte{0xa5, 0x01})
+			convey.So(err.Error(), convey.ShouldEqual, "cbor: unexpected end of data")
+		})
+	})
+}
@@ -2,33 +2,75 @@ package server
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"github.com/yusufsyaifudin/go-jwt-login-example/apidoc"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/app/admin"
 	"github.com/yusufsyaifudin/go-jwt-login-example/internal/app/user"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/app/wellknown"
 	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
 	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/db"
 	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/http"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/lockout"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/mail"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/passwordpolicy"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/ratelimit"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/webauthn"
 )
 
 var logger = log.With().Str("pkg", "server").Logger()
 var stopped = false
 
 type Config struct {
-	ListenAddress   string
-	ServerSecretKey string
-	DB              db.Query
-	Auth            auth.Auth
+	ListenAddress    string
+	ServerSecretKey  string
+	DB               db.Query
+	Auth             auth.Auth
+	Issuer           string // url of this server, used as iss claim of ID token
+	Audience         string // client id of our frontend, used as aud claim of ID token
+	Mailer           mail.Mailer
+	ResetPasswordURL string // page in our frontend to enter the new password
+	VerifyEmailURL   string // page in our frontend to verify the email
+	WebAuthn         webauthn.Config
+	LoginAttempts    lockout.Store            // failed login, in memory when it is nil
+	RateLimits       ratelimit.Store          // request count of rate limit, in memory when it is nil
+	PasswordHasher   user.PasswordHasher      // hash new password, argon2id when it is nil
+	PasswordPolicy   *passwordpolicy.Policy   // rules of new password, passwordpolicy.DefaultPolicy when it is nil
+	ErrorFormat      http.ErrorFormat         // body of error response, client can still ask application/problem+json using Accept header
+	ProblemTypeURL   string                   // base url of problem type in application/problem+json, about:blank when it is empty
+	RequestTimeout   time.Duration            // cancel the handler context after this duration, zero means no timeout
+	RouteTimeouts    map[string]time.Duration // timeout of some routes, the key is method and full path like "POST /api/v1/user/login"
+	TrustedProxies   http.TrustedProxies      // client ip is read from X-Forwarded-For only when the request comes from these proxies
+
+	// when true, user cannot login until the email is verified
+	RequireVerifiedEmail bool
 }
 
 // Run will run the server and return error if error occurred.
 func (config *Config) Run() error {
+	gin.SetMode(gin.ReleaseMode)
+	router := config.Router()
+
+	// for debugging purpose
+	for _, routeInfo := range router.Routes() {
+		logger.Debug().
+			Str("path", routeInfo.Path).
+			Str("handler", routeInfo.Handler).
+			Str("method", routeInfo.Method).
+			Msg("registered routes")
+	}
+
+	return router.Run(config.ListenAddress)
+}
+
+// Router registers all routes without listening, so the server can be tested using httptest.
+func (config *Config) Router() *gin.Engine {
 	parentCtx := context.Background()
-	defer parentCtx.Done()
 
-	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(Logger())
 
@@ -46,46 +88,120 @@ func (config *Config) Run() error {
 		ctx.Next()
 	})
 
-	router.NoRoute(func(ctx *gin.Context) {
-		response := map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "route not found",
-			},
+	// every route responds error using the configured format, and knows the client ip behind the proxy
+	errorFormat := http.ProblemDetails(config.ErrorFormat, config.ProblemTypeURL)
+	trustProxies := http.TrustProxies(config.TrustedProxies)
+	wrap := func(handler http.Handler) gin.HandlerFunc {
+		return http.WrapGin(parentCtx, trustProxies(errorFormat(handler)))
+	}
+
+	// route registers the handler with its timeout, key of RouteTimeouts is the method and full path, like "POST /api/v1/user/login"
+	route := func(group *gin.RouterGroup, method, path string, handler http.Handler) {
+		timeout := config.RequestTimeout
+		if routeTimeout, ok := config.RouteTimeouts[method+" "+strings.TrimSuffix(group.BasePath(), "/")+path]; ok {
+			timeout = routeTimeout
 		}
 
-		ctx.JSON(404, response)
-		ctx.Abort()
-	})
+		group.Handle(method, path, wrap(http.Timeout(timeout)(handler)))
+	}
 
-	router.NoMethod(func(ctx *gin.Context) {
-		response := map[string]interface{}{
-			"error": map[string]interface{}{
-				"message": "method for this route not found",
-			},
-		}
+	// without this, gin calls NoRoute for wrong method too
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(wrap(http.NotFoundHandler))
+	router.NoMethod(wrap(http.MethodNotAllowedHandler))
 
-		ctx.JSON(404, response)
-		ctx.Abort()
-	})
+	wellKnownHandler := wellknown.NewWellKnownHandler(config.Issuer, config.Auth)
+	route(&router.RouterGroup, "GET", "/.well-known/jwks.json", wellKnownHandler.JWKSHandler)
+	route(&router.RouterGroup, "GET", "/.well-known/openid-configuration", wellKnownHandler.OpenIDConfigurationHandler)
 
 	userHandler := user.NewUserHandler(config.ServerSecretKey, config.DB, config.Auth)
-	protectedMiddleware := http.ChainMiddleware(userHandler.MiddlewareAuthTokenCheck)
+	userHandler.Issuer = config.Issuer
+	userHandler.Audience = config.Audience
+	userHandler.ResetPasswordURL = config.ResetPasswordURL
+	userHandler.VerifyEmailURL = config.VerifyEmailURL
+	userHandler.RequireVerifiedEmail = config.RequireVerifiedEmail
+	userHandler.WebAuthn = config.WebAuthn
+	if config.Mailer != nil {
+		userHandler.Mailer = config.Mailer
+	}
 
-	userGroup := router.Group("/api/v1/user")
-	userGroup.POST("/login", http.WrapGin(parentCtx, userHandler.LoginUserHandler))
-	userGroup.POST("/register", http.WrapGin(parentCtx, userHandler.RegisterUserHandler))
-	userGroup.GET("/profile", http.WrapGin(parentCtx, protectedMiddleware(userHandler.ProfileUserHandler)))
+	if config.PasswordHasher != nil {
+		userHandler.PasswordHasher = config.PasswordHasher
+	}
 
-	// for debugging purpose
-	for _, routeInfo := range router.Routes() {
-		logger.Debug().
-			Str("path", routeInfo.Path).
-			Str("handler", routeInfo.Handler).
-			Str("method", routeInfo.Method).
-			Msg("registered routes")
+	if config.PasswordPolicy != nil {
+		userHandler.PasswordPolicy = *config.PasswordPolicy
 	}
 
-	return router.Run(config.ListenAddress)
+	if config.LoginAttempts != nil {
+		userHandler.LoginLockout = user.NewLoginLockout(config.LoginAttempts)
+	}
+
+	// all end-points below are about the user, so service client token is rejected.
+	// End-point which can be called by service client only needs MiddlewareAuthTokenCheck.
+	protectedMiddleware := http.ChainMiddleware(userHandler.MiddlewareAuthTokenCheck, userHandler.MiddlewareRequireUser)
+
+	// end-points which can be abused: register creates user, and forgot password or resend verification sends email
+	rateLimitStore := config.RateLimits
+	if rateLimitStore == nil {
+		rateLimitStore = ratelimit.NewMemoryStore()
+	}
+
+	registerRateLimit := http.RateLimit("register", ratelimit.NewLimiter(rateLimitStore, 10, time.Hour), http.KeyByIP)
+	mailRateLimit := http.RateLimit("mail", ratelimit.NewLimiter(rateLimitStore, 10, time.Hour), http.KeyByIP)
+	profileMiddleware := http.ChainMiddleware(
+		protectedMiddleware,
+		http.RateLimit("profile", ratelimit.NewLimiter(rateLimitStore, 60, time.Minute), http.KeyByUser),
+	)
+
+	// userinfo is the only end-point which accepts the token issued to OAuth client
+	userInfoMiddleware := http.ChainMiddleware(userHandler.MiddlewareOAuthTokenCheck, userHandler.MiddlewareRequireUser)
+	route(&router.RouterGroup, "GET", "/userinfo", userInfoMiddleware(userHandler.UserInfoHandler))
+	route(&router.RouterGroup, "POST", "/userinfo", userInfoMiddleware(userHandler.UserInfoHandler))
+
+	oauthGroup := router.Group("/oauth")
+	route(oauthGroup, "GET", "/authorize", userHandler.AuthorizeHandler)
+	route(oauthGroup, "POST", "/authorize", userHandler.AuthorizeSubmitHandler)
+	route(oauthGroup, "POST", "/token", userHandler.OAuthTokenHandler)
+
+	userGroup := router.Group("/api/v1/user")
+	route(userGroup, "POST", "/login", userHandler.LoginUserHandler)
+	route(userGroup, "POST", "/login/mfa", userHandler.LoginMFAHandler)
+	route(userGroup, "POST", "/register", registerRateLimit(userHandler.RegisterUserHandler))
+	route(userGroup, "POST", "/token/refresh", userHandler.RefreshTokenHandler)
+	route(userGroup, "POST", "/password/forgot", mailRateLimit(userHandler.ForgotPasswordHandler))
+	route(userGroup, "POST", "/password/reset", userHandler.ResetPasswordHandler)
+	route(userGroup, "POST", "/email/verify", userHandler.VerifyEmailHandler)
+	route(userGroup, "POST", "/email/verify/resend", mailRateLimit(userHandler.ResendEmailVerificationHandler))
+	route(userGroup, "POST", "/webauthn/login/options", userHandler.WebAuthnLoginOptionsHandler)
+	route(userGroup, "POST", "/webauthn/login", userHandler.WebAuthnLoginHandler)
+	route(userGroup, "GET", "/profile", profileMiddleware(userHandler.ProfileUserHandler))
+	route(userGroup, "POST", "/logout", protectedMiddleware(userHandler.LogoutUserHandler))
+	route(userGroup, "POST", "/password", protectedMiddleware(userHandler.ChangePasswordHandler))
+	route(userGroup, "POST", "/mfa/totp/setup", protectedMiddleware(userHandler.TOTPSetupHandler))
+	route(userGroup, "POST", "/mfa/totp/confirm", protectedMiddleware(userHandler.TOTPConfirmHandler))
+	route(userGroup, "POST", "/mfa/totp/disable", protectedMiddleware(userHandler.TOTPDisableHandler))
+	route(userGroup, "POST", "/webauthn/register/options", protectedMiddleware(userHandler.WebAuthnRegisterOptionsHandler))
+	route(userGroup, "POST", "/webauthn/register", protectedMiddleware(userHandler.WebAuthnRegisterHandler))
+	route(userGroup, "GET", "/webauthn/credentials", protectedMiddleware(userHandler.WebAuthnCredentialsHandler))
+	route(userGroup, "DELETE", "/webauthn/credentials/:id", protectedMiddleware(userHandler.WebAuthnDeleteCredentialHandler))
+
+	adminHandler := admin.NewAdminHandler(config.DB)
+	adminHandler.PasswordHasher = userHandler.PasswordHasher
+	adminHandler.PasswordPolicy = userHandler.PasswordPolicy
+	adminMiddleware := http.ChainMiddleware(userHandler.MiddlewareAuthTokenCheck, userHandler.MiddlewareRequireUser, user.RequireRole("admin"))
+
+	adminGroup := router.Group("/api/v1/admin")
+	route(adminGroup, "GET", "/users", adminMiddleware(adminHandler.ListUsersHandler))
+	route(adminGroup, "GET", "/users/:id", adminMiddleware(adminHandler.GetUserHandler))
+	route(adminGroup, "PATCH", "/users/:id", adminMiddleware(adminHandler.UpdateUserHandler))
+	route(adminGroup, "DELETE", "/users/:id", adminMiddleware(adminHandler.DeleteUserHandler))
+	route(adminGroup, "POST", "/users/:id/password", adminMiddleware(adminHandler.ResetUserPasswordHandler))
+	route(adminGroup, "POST", "/users/:id/disable", adminMiddleware(adminHandler.DisableUserHandler))
+	route(adminGroup, "POST", "/users/:id/enable", adminMiddleware(adminHandler.EnableUserHandler))
+	route(adminGroup, "POST", "/users/:id/mfa/reset", adminMiddleware(adminHandler.ResetUserMFAHandler))
+
+	return router
 }
 
 // Shutdown this package
@@ -0,0 +1,367 @@
+package server_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/model"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+	"github.com/yusufsyaifudin/go-jwt-login-example/server/servertest"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testPassword = "correct horse battery"
+
+// register creates the user using the api and returns the access token
+func register(s *servertest.Server, username, email string) (accessToken string, userID int64) {
+	resp := s.Do("POST", "/api/v1/user/register", map[string]interface{}{
+		"name":     "User " + username,
+		"username": username,
+		"email":    email,
+		"password": testPassword,
+	}, "")
+
+	body := resp.JSON()
+	accessToken, _ = body["access_token"].(string)
+	if user, ok := body["user"].(map[string]interface{}); ok {
+		id, _ := user["id"].(float64)
+		userID = int64(id)
+	}
+
+	return
+}
+
+// errorCode returns code of the error envelope
+func errorCode(resp *servertest.Response) string {
+	apiError, _ := resp.JSON()["error"].(map[string]interface{})
+	code, _ := apiError["code"].(string)
+	return code
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Register using in-memory database", t, func() {
+		s := servertest.New()
+
+		convey.Convey("When the form is valid, user is saved and the tokens are returned", func() {
+			resp := s.Do("POST", "/api/v1/user/register", map[string]interface{}{
+				"name":     "Alice",
+				"username": "alice",
+				"email":    "Alice@Example.com",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 200)
+			convey.So(resp.JSON()["access_token"], convey.ShouldNotBeEmpty)
+			convey.So(resp.JSON()["refresh_token"], convey.ShouldNotBeEmpty)
+
+			user, ok := s.DB.User(1)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(user.Username, convey.ShouldEqual, "alice")
+			convey.So(user.Email, convey.ShouldEqual, "alice@example.com")
+			convey.So(user.Password, convey.ShouldNotEqual, testPassword)
+		})
+
+		convey.Convey("When the username is taken", func() {
+			register(s, "alice", "")
+			resp := s.Do("POST", "/api/v1/user/register", map[string]interface{}{
+				"name":     "Other Alice",
+				"username": "alice",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 409)
+			convey.So(errorCode(resp), convey.ShouldEqual, "username_taken")
+		})
+
+		convey.Convey("When the email is taken", func() {
+			register(s, "alice", "alice@example.com")
+			resp := s.Do("POST", "/api/v1/user/register", map[string]interface{}{
+				"name":     "Bob",
+				"username": "bob",
+				"email":    "alice@example.com",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 409)
+			convey.So(errorCode(resp), convey.ShouldEqual, "email_taken")
+		})
+
+		convey.Convey("When required field is missing", func() {
+			resp := s.Do("POST", "/api/v1/user/register", map[string]interface{}{
+				"username": "alice",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 422)
+			convey.So(errorCode(resp), convey.ShouldEqual, "validation_failed")
+
+			_, ok := s.DB.User(1)
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+
+		convey.Convey("When the password is too short", func() {
+			resp := s.Do("POST", "/api/v1/user/register", map[string]interface{}{
+				"name":     "Alice",
+				"username": "alice",
+				"password": "abc1",
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 422)
+			convey.So(errorCode(resp), convey.ShouldEqual, "validation_failed")
+		})
+
+		convey.Convey("When some fields are not valid, all of them are sent in one response", func() {
+			resp := s.Do("POST", "/api/v1/user/register", map[string]interface{}{
+				"username": "alice",
+				"email":    "not an email",
+				"password": "abc1",
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 422)
+			convey.So(errorCode(resp), convey.ShouldEqual, "validation_failed")
+
+			var fields []string
+			details, _ := resp.JSON()["error"].(map[string]interface{})["details"].([]interface{})
+			for _, detail := range details {
+				fields = append(fields, detail.(map[string]interface{})["field"].(string))
+			}
+
+			convey.So(fields, convey.ShouldContain, "name")
+			convey.So(fields, convey.ShouldContain, "email")
+			convey.So(fields, convey.ShouldContain, "password")
+		})
+	})
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Login using in-memory database", t, func() {
+		s := servertest.New()
+		_, userID := register(s, "alice", "")
+
+		convey.Convey("When the password is correct", func() {
+			resp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+				"username": "alice",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 200)
+			convey.So(resp.JSON()["access_token"], convey.ShouldNotBeEmpty)
+			convey.So(resp.JSON()["user"].(map[string]interface{})["id"], convey.ShouldEqual, float64(userID))
+		})
+
+		convey.Convey("When the password is wrong", func() {
+			resp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+				"username": "alice",
+				"password": "wrong password",
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 401)
+			convey.So(errorCode(resp), convey.ShouldEqual, "invalid_credentials")
+		})
+
+		convey.Convey("When the username is not registered, the response is the same as wrong password", func() {
+			resp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+				"username": "bob",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 401)
+			convey.So(errorCode(resp), convey.ShouldEqual, "invalid_credentials")
+		})
+
+		convey.Convey("When the password is wrong too many times, even the correct password must wait", func() {
+			for i := 0; i < 4; i++ {
+				resp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+					"username": "alice",
+					"password": "wrong password",
+				}, "")
+				convey.So(resp.Code, convey.ShouldEqual, 401)
+			}
+
+			resp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+				"username": "alice",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 429)
+			convey.So(errorCode(resp), convey.ShouldEqual, "too_many_attempts")
+			convey.So(resp.Header.Get("Retry-After"), convey.ShouldNotBeEmpty)
+		})
+
+		convey.Convey("When the current password in change password is wrong too many times, login must wait too", func() {
+			loginResp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+				"username": "alice",
+				"password": testPassword,
+			}, "")
+			accessToken, _ := loginResp.JSON()["access_token"].(string)
+
+			for i := 0; i < 4; i++ {
+				resp := s.Do("POST", "/api/v1/user/password", map[string]interface{}{
+					"current_password": "wrong password",
+					"new_password":     "another horse battery",
+				}, accessToken)
+				convey.So(resp.Code, convey.ShouldEqual, 400)
+			}
+
+			resp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+				"username": "alice",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 429)
+			convey.So(errorCode(resp), convey.ShouldEqual, "too_many_attempts")
+		})
+
+		convey.Convey("When the user is disabled", func() {
+			s.DB.UpdateUser(userID, func(user *model.User) {
+				now := time.Now()
+				user.DisabledAt = &now
+			})
+
+			resp := s.Do("POST", "/api/v1/user/login", map[string]interface{}{
+				"username": "alice",
+				"password": testPassword,
+			}, "")
+
+			convey.So(resp.Code, convey.ShouldEqual, 403)
+			convey.So(errorCode(resp), convey.ShouldEqual, "user_disabled")
+		})
+	})
+}
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Get profile using the access token", t, func() {
+		s := servertest.New()
+		accessToken, userID := register(s, "alice", "alice@example.com")
+
+		resp := s.Do("GET", "/api/v1/user/profile", nil, accessToken)
+		convey.So(resp.Code, convey.ShouldEqual, 200)
+
+		user := resp.JSON()["user"].(map[string]interface{})
+		convey.So(user["id"], convey.ShouldEqual, float64(userID))
+		convey.So(user["username"], convey.ShouldEqual, "alice")
+		convey.So(user["email"], convey.ShouldEqual, "alice@example.com")
+		convey.So(user["email_verified"], convey.ShouldBeFalse)
+		convey.So(user["mfa_enabled"], convey.ShouldBeFalse)
+	})
+}
+
+func TestMiddlewareAuthTokenCheck(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Protected end-point checks the access token", t, func() {
+		s := servertest.New()
+		accessToken, userID := register(s, "alice", "")
+
+		convey.Convey("When the token is not sent", func() {
+			resp := s.Do("GET", "/api/v1/user/profile", nil, "")
+			convey.So(resp.Code, convey.ShouldEqual, 401)
+			convey.So(errorCode(resp), convey.ShouldEqual, "invalid_token")
+		})
+
+		convey.Convey("When the token is not valid", func() {
+			resp := s.Do("GET", "/api/v1/user/profile", nil, accessToken+"x")
+			convey.So(resp.Code, convey.ShouldEqual, 401)
+			convey.So(errorCode(resp), convey.ShouldEqual, "invalid_token")
+		})
+
+		convey.Convey("When the token is sent in the body", func() {
+			resp := s.Do("POST", "/userinfo", map[string]interface{}{
+				"access_token": accessToken,
+			}, "")
+			convey.So(resp.Code, convey.ShouldEqual, 200)
+		})
+
+		convey.Convey("When the user is disabled", func() {
+			s.DB.UpdateUser(userID, func(user *model.User) {
+				now := time.Now()
+				user.DisabledAt = &now
+			})
+
+			resp := s.Do("GET", "/api/v1/user/profile", nil, accessToken)
+			convey.So(resp.Code, convey.ShouldEqual, 403)
+			convey.So(errorCode(resp), convey.ShouldEqual, "user_disabled")
+		})
+
+		convey.Convey("When the token version is older than the user", func() {
+			s.DB.UpdateUser(userID, func(user *model.User) {
+				user.TokenVersion++
+			})
+
+			resp := s.Do("GET", "/api/v1/user/profile", nil, accessToken)
+			convey.So(resp.Code, convey.ShouldEqual, 401)
+			convey.So(errorCode(resp), convey.ShouldEqual, "token_revoked")
+		})
+
+		convey.Convey("When the token is revoked by logout", func() {
+			resp := s.Do("POST", "/api/v1/user/logout", nil, accessToken)
+			convey.So(resp.Code, convey.ShouldEqual, 200)
+
+			resp = s.Do("GET", "/api/v1/user/profile", nil, accessToken)
+			convey.So(resp.Code, convey.ShouldEqual, 401)
+			convey.So(errorCode(resp), convey.ShouldEqual, "token_revoked")
+		})
+
+		convey.Convey("When the token belongs to user without required role", func() {
+			resp := s.Do("GET", "/api/v1/admin/users", nil, accessToken)
+			convey.So(resp.Code, convey.ShouldEqual, 403)
+		})
+
+		convey.Convey("When the token is issued to OAuth client", func() {
+			s.DB.GrantRole(userID, "admin")
+			clientToken, err := s.Config.Auth.GenerateToken(&auth.Payload{
+				ID:              strconv.FormatInt(userID, 10),
+				Username:        "alice",
+				AuthorizedParty: "third-party",
+				Scope:           "openid profile",
+				JTI:             "client-bound",
+				IssuedAt:        time.Now().Unix(),
+				NotBefore:       time.Now().Unix(),
+				ExpiredAt:       time.Now().Add(time.Hour).Unix(),
+			}, s.Config.ServerSecretKey)
+			convey.So(err, convey.ShouldBeNil)
+
+			resp := s.Do("GET", "/userinfo", nil, clientToken)
+			convey.So(resp.Code, convey.ShouldEqual, 200)
+
+			for _, path := range []string{"/api/v1/user/profile", "/api/v1/admin/users"} {
+				resp = s.Do("GET", path, nil, clientToken)
+				convey.So(resp.Code, convey.ShouldEqual, 403)
+				convey.So(errorCode(resp), convey.ShouldEqual, "forbidden")
+			}
+		})
+	})
+}
+
+func TestAuthorizeSubmit(t *testing.T) {
+	t.Parallel()
+
+	convey.Convey("Submit login and consent page without csrf token from the page", t, func() {
+		s := servertest.New()
+		register(s, "alice", "")
+
+		resp := s.Do("POST", "/oauth/authorize", map[string]interface{}{
+			"response_type": "code",
+			"client_id":     "third-party",
+			"username":      "alice",
+			"password":      testPassword,
+			"consent":       "allow",
+			"csrf_token":    "guessed",
+		}, "")
+
+		convey.So(resp.Code, convey.ShouldEqual, 403)
+		convey.So(resp.Header.Get("Location"), convey.ShouldBeEmpty)
+	})
+}
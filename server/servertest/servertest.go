@@ -0,0 +1,95 @@
+// Package servertest boots server.Config with the in-memory database, so the api can be tested end-to-end
+// using go test without Postgres or other external services.
+package servertest
+
+import (
+	"bytes"
+	"encoding/json"
+	stdhttp "net/http"
+	"net/http/httptest"
+
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/app/user"
+	"github.com/yusufsyaifudin/go-jwt-login-example/internal/pkg/memdb"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/auth"
+	"github.com/yusufsyaifudin/go-jwt-login-example/pkg/mail"
+	"github.com/yusufsyaifudin/go-jwt-login-example/server"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Server is the router of server.Config which uses DB as the database
+type Server struct {
+	DB     *memdb.DB
+	Config *server.Config
+	router stdhttp.Handler
+}
+
+// New creates the server with empty database. Every server has its own rate limit and lockout in memory,
+// so create one server per test. Use configure to change the config before the routes are registered.
+func New(configure ...func(config *server.Config)) *Server {
+	database := memdb.New()
+	config := &server.Config{
+		ServerSecretKey: "servertest-secret-key",
+		DB:              database,
+		Auth:            auth.NewJwtAuth(),
+		Issuer:          "http://localhost",
+		Audience:        "servertest",
+		Mailer:          mail.NewLogMailer(),
+		PasswordHasher:  user.NewBcryptHasher(bcrypt.MinCost), // the default argon2id is too slow for tests
+	}
+
+	for _, fn := range configure {
+		fn(config)
+	}
+
+	return &Server{
+		DB:     database,
+		Config: config,
+		router: config.Router(),
+	}
+}
+
+// Response is the recorded response of Do
+type Response struct {
+	Code   int
+	Header stdhttp.Header
+	Body   []byte
+}
+
+// JSON decodes the body as json object, it returns nil when the body is not json object
+func (resp *Response) JSON() map[string]interface{} {
+	var body map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil
+	}
+
+	return body
+}
+
+// Do sends the request to the router. body is encoded as json when it is not nil,
+// and token is sent as bearer token in Authorization header when it is not empty.
+func (s *Server) Do(method, path string, body interface{}, token string) *Response {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			panic(err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &payload)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, req)
+
+	return &Response{
+		Code:   recorder.Code,
+		Header: recorder.Header(),
+		Body:   recorder.Body.Bytes(),
+	}
+}